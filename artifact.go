@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -26,18 +27,24 @@ func (a *artifactImpl) PathAfterFinished() (string, error) {
 }
 
 func (a *artifactImpl) SaveAs(path string) error {
+	return a.channel.instrumentAPICall(func(ctx context.Context) error {
+		return a.saveAs(ctx, path)
+	})
+}
+
+func (a *artifactImpl) saveAs(ctx context.Context, path string) error {
 	if !a.connection.isRemote {
-		_, err := a.channel.Send("saveAs", map[string]any{
+		_, err := a.channel.within(ctx).Send("saveAs", map[string]any{
 			"path": path,
 		})
 		return err
 	}
-	streamChannel, err := a.channel.Send("saveAsStream")
+	streamChannel, err := a.channel.within(ctx).Send("saveAsStream")
 	if err != nil {
 		return err
 	}
 	stream := fromChannel(streamChannel).(*streamImpl)
-	return stream.SaveAs(path)
+	return stream.SaveAs(ctx, path)
 }
 
 func (a *artifactImpl) Failure() error {
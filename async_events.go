@@ -0,0 +1,130 @@
+package playwright
+
+import (
+	"slices"
+	"sync"
+)
+
+// OverflowPolicy says what happens to an event whose handlers are queued
+// while the queue is full, see [AsyncEventOptions].
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest drops the event that does not fit. It is the default.
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest queued event to make room.
+	OverflowDropOldest
+	// OverflowBlock stops reading messages from the driver until the handlers
+	// of the object catch up. A handler that then waits for a reply from the
+	// driver, e.g. Response.Body, never gets it and deadlocks the connection:
+	// only opt into it when handlers make no Playwright calls.
+	OverflowBlock
+)
+
+// AsyncEventOptions runs event handlers off the goroutine that receives the
+// driver's messages, see [RunOptions.AsyncEvents].
+type AsyncEventOptions struct {
+	// BufferSize is the number of events queued per object before Overflow
+	// applies. Defaults to 256.
+	BufferSize int
+	// Overflow applies to events that do not fit in the queue of their object.
+	// Dropped events are logged as warnings. Defaults to OverflowDropNewest.
+	// The "close", "crash", "disconnected" and "dialog" events are never
+	// dropped and never block, and the waits of playwright-go itself, e.g.
+	// Page.ExpectResponse, do not go through the queue.
+	Overflow OverflowPolicy
+}
+
+// keptEvents are queued even when the queue is full: the lifecycle events, and
+// dialogs, which would stall their page if they were dropped.
+var keptEvents = map[string]bool{"close": true, "crash": true, "disconnected": true, "dialog": true}
+
+// subscribeInternal subscribes a handler of playwright-go itself, e.g. of a
+// waiter, to emitter. Unlike the handlers of users, it is called when the
+// event is emitted even when those are deferred or run asynchronously, so it
+// neither waits behind them nor is dropped with them.
+func subscribeInternal(emitter EventEmitter, name string, handler any, once bool) Subscription {
+	s, ok := emitter.(subscriber)
+	if !ok {
+		if once {
+			return emitter.Once(name, handler)
+		}
+		return emitter.On(name, handler)
+	}
+	return s.subscribe(name, listener{handler: handler, once: once, internal: true})
+}
+
+func isInternalListener(l listener) bool { return l.internal }
+
+func isUserListener(l listener) bool { return !l.internal }
+
+// handlerQueue runs the handlers of the events of one object in order, on a
+// goroutine that is started when an event is queued and exits when the queue
+// is empty.
+type handlerQueue struct {
+	options AsyncEventOptions
+	mu      sync.Mutex
+	notFull *sync.Cond
+	events  []queuedEvent
+	running bool
+}
+
+type queuedEvent struct {
+	handlers func()
+	// keep exempts the event from Overflow.
+	keep bool
+}
+
+func newHandlerQueue(options AsyncEventOptions) *handlerQueue {
+	if options.BufferSize <= 0 {
+		options.BufferSize = 256
+	}
+	q := &handlerQueue{options: options}
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues fn and reports whether it did and whether fn or an older event
+// was dropped. With keep, fn is queued right away even when the queue is full.
+func (q *handlerQueue) push(fn func(), keep bool) (queued, dropped bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !keep && len(q.events) >= q.options.BufferSize {
+		switch q.options.Overflow {
+		case OverflowBlock:
+			q.notFull.Wait()
+		case OverflowDropOldest:
+			oldest := slices.IndexFunc(q.events, func(event queuedEvent) bool { return !event.keep })
+			if oldest < 0 {
+				return false, true
+			}
+			q.events = slices.Delete(q.events, oldest, oldest+1)
+			dropped = true
+		default:
+			return false, true
+		}
+	}
+	q.events = append(q.events, queuedEvent{handlers: fn, keep: keep})
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+	return true, dropped
+}
+
+func (q *handlerQueue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.events) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		event := q.events[0]
+		q.events[0] = queuedEvent{}
+		q.events = q.events[1:]
+		q.notFull.Signal()
+		q.mu.Unlock()
+		event.handlers()
+	}
+}
@@ -0,0 +1,142 @@
+package playwright
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncEventHandlers(t *testing.T) {
+	c, page, transport := newEchoConnection(t)
+	c.asyncEvents = &AsyncEventOptions{}
+	page.channel.On("ping", func(ev map[string]any) { page.Emit("ping", ev["n"]) })
+
+	// The handler makes a call while the receive loop keeps dispatching.
+	results := make(chan any, 3)
+	page.On("ping", func(n any) {
+		_, err := page.channel.Send("title")
+		require.NoError(t, err)
+		require.False(t, c.handling.Load())
+		results <- n
+	})
+	for n := 1; n <= 3; n++ {
+		transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": n}})
+	}
+	for n := 1; n <= 3; n++ {
+		require.Equal(t, n, <-results)
+	}
+	require.False(t, page.Emit("unknown"))
+}
+
+func TestAsyncEventHandlersOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		policy OverflowPolicy
+		want   []int
+	}{
+		{OverflowDropNewest, []int{1, 2}},
+		{OverflowDropOldest, []int{1, 3}},
+	} {
+		_, page, _ := newEchoConnection(t)
+		page.connection.asyncEvents = &AsyncEventOptions{BufferSize: 1, Overflow: tc.policy}
+		started, release := make(chan struct{}), make(chan struct{})
+		var got []int
+		done := make(chan struct{})
+		page.On("ping", func(n int) {
+			if n == 1 {
+				close(started)
+				<-release
+			}
+			got = append(got, n)
+			if len(got) == 2 {
+				close(done)
+			}
+		})
+		page.Emit("ping", 1)
+		<-started
+		// 1 is running: 2 fills the queue and 3 overflows.
+		page.Emit("ping", 2)
+		page.Emit("ping", 3)
+		close(release)
+		<-done
+		require.Equal(t, tc.want, got)
+	}
+}
+
+func TestHandlerQueueBlocks(t *testing.T) {
+	q := newHandlerQueue(AsyncEventOptions{BufferSize: 1, Overflow: OverflowBlock})
+	release := make(chan struct{})
+	ran := make(chan int, 3)
+	for n := 1; n <= 2; n++ {
+		queued, _ := q.push(func() { <-release; ran <- n }, false)
+		require.True(t, queued)
+	}
+	pushed := make(chan struct{})
+	go func() {
+		q.push(func() { ran <- 3 }, false)
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("push did not block on a full queue")
+	default:
+	}
+	close(release)
+	<-pushed
+	require.Equal(t, 1, <-ran)
+	require.Equal(t, 2, <-ran)
+	require.Equal(t, 3, <-ran)
+}
+
+func TestHandlerQueueDropsByDefault(t *testing.T) {
+	q := newHandlerQueue(AsyncEventOptions{BufferSize: 1})
+	release := make(chan struct{})
+	defer close(release)
+	queued, _ := q.push(func() { <-release }, false)
+	require.True(t, queued)
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return len(q.events) == 0
+	}, time.Second, time.Millisecond)
+	queued, dropped := q.push(func() {}, false)
+	require.True(t, queued)
+	require.False(t, dropped)
+	queued, dropped = q.push(func() {}, false)
+	require.False(t, queued)
+	require.True(t, dropped)
+	// Kept events are queued anyway.
+	queued, dropped = q.push(func() {}, true)
+	require.True(t, queued)
+	require.False(t, dropped)
+}
+
+func TestAsyncEventsFullQueue(t *testing.T) {
+	_, page, _ := newEchoConnection(t)
+	page.connection.asyncEvents = &AsyncEventOptions{BufferSize: 1}
+	started, release := make(chan struct{}), make(chan struct{})
+	page.On("ping", func(n int) {
+		if n == 1 {
+			close(started)
+			<-release
+		}
+	})
+	closed := make(chan struct{})
+	page.On("close", func() { close(closed) })
+	page.Emit("ping", 1)
+	<-started
+	// 1 is running and 2 fills the queue.
+	page.Emit("ping", 2)
+
+	// A pending wait sees the event although its user handlers are dropped.
+	waiter := newWaiter().WithTimeout(1000).WaitForEvent(page, "ping", nil)
+	require.True(t, page.Emit("ping", 3))
+	got, err := waiter.Wait()
+	require.NoError(t, err)
+	require.Equal(t, 3, got)
+
+	// Lifecycle events are queued anyway.
+	require.True(t, page.Emit("close"))
+	close(release)
+	<-closed
+}
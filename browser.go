@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -153,7 +154,13 @@ func (b *browserImpl) Unbind() error {
 	return err
 }
 
-func (b *browserImpl) Close(options ...BrowserCloseOptions) (err error) {
+func (b *browserImpl) Close(options ...BrowserCloseOptions) error {
+	return b.channel.instrumentAPICall(func(ctx context.Context) error {
+		return b.close(ctx, options...)
+	})
+}
+
+func (b *browserImpl) close(ctx context.Context, options ...BrowserCloseOptions) (err error) {
 	if len(options) == 1 {
 		b.closeReason = options[0].Reason
 	}
@@ -161,11 +168,11 @@ func (b *browserImpl) Close(options ...BrowserCloseOptions) (err error) {
 	if b.shouldCloseConnectionOnClose {
 		err = b.connection.Stop()
 	} else if b.closeReason != nil {
-		_, err = b.channel.Send("close", map[string]any{
+		_, err = b.channel.within(ctx).Send("close", map[string]any{
 			"reason": b.closeReason,
 		})
 	} else {
-		_, err = b.channel.Send("close")
+		_, err = b.channel.within(ctx).Send("close")
 	}
 	if err != nil && !errors.Is(err, ErrTargetClosed) {
 		return fmt.Errorf("close browser failed: %w", err)
@@ -236,12 +243,12 @@ func (b *browserImpl) onClose() {
 	b.Unlock()
 }
 
-func (b *browserImpl) OnDisconnected(fn func(Browser)) {
-	b.On("disconnected", fn)
+func (b *browserImpl) OnDisconnected(fn func(Browser)) Subscription {
+	return b.On("disconnected", fn)
 }
 
-func (b *browserImpl) OnContext(fn func(BrowserContext)) {
-	b.On("context", fn)
+func (b *browserImpl) OnContext(fn func(BrowserContext)) Subscription {
+	return b.On("context", fn)
 }
 
 func newBrowser(parent *channelOwner, objectType string, guid string, initializer map[string]any) *browserImpl {
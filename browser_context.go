@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,13 @@ import (
 )
 
 type browserContextImpl struct {
+	*browserContextState
+	// channel is bound to a context in the views returned by WithContext,
+	// which share the browserContextState of the context.
+	channel *channel
+}
+
+type browserContextState struct {
 	channelOwner
 	timeoutSettings *timeoutSettings
 	closeWasCalled  atomic.Bool
@@ -41,6 +49,10 @@ type browserContextImpl struct {
 	credentials     Credentials
 }
 
+func (b *browserContextImpl) WithContext(ctx context.Context) BrowserContext {
+	return &browserContextImpl{browserContextState: b.browserContextState, channel: b.channelOwner.channel.withContext(ctx)}
+}
+
 func (b *browserContextImpl) Clock() Clock {
 	return b.clock
 }
@@ -369,7 +381,7 @@ func (b *browserContextImpl) waiterForEvent(event string, options ...BrowserCont
 		}
 		predicate = options[0].Predicate
 	}
-	waiter := newWaiter().WithTimeout(timeout)
+	waiter := newWaiter().WithChannel(b.channel).WithTimeout(timeout)
 	// Don't reject on the very event being awaited.
 	if event != "close" {
 		waiter.RejectOnEvent(b, "close", targetClosedError(b.effectiveCloseReason()))
@@ -419,6 +431,12 @@ func (b *browserContextImpl) ExpectPage(cb func() error, options ...BrowserConte
 }
 
 func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error {
+	return b.channel.instrumentAPICall(func(ctx context.Context) error {
+		return b.close(ctx, options...)
+	})
+}
+
+func (b *browserContextImpl) close(ctx context.Context, options ...BrowserContextCloseOptions) error {
 	// Mirror upstream's `if (this.isClosed()) return;` guard, where isClosed() is
 	// `_closingStatus !== 'none'` (true once closing OR closed). Guarding only on
 	// closeWasCalled would let a Close() after a server-driven close proceed into
@@ -431,8 +449,8 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 	}
 	b.closeWasCalled.Store(true)
 
-	_, err := b.channel.connection.WrapAPICall(func() (any, error) {
-		return nil, b.request.Dispose(APIRequestContextDisposeOptions{
+	_, err := b.channel.connection.wrapAPICall(nil, "", nil, ctx, func(ctx context.Context) (any, error) {
+		return nil, b.request.dispose(ctx, APIRequestContextDisposeOptions{
 			Reason: b.closeReason,
 		})
 	}, true)
@@ -440,7 +458,7 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 		return err
 	}
 
-	innerClose := func() (any, error) {
+	innerClose := func(ctx context.Context) (any, error) {
 		for harId, harMetaData := range b.harRecorders {
 			overrides := map[string]any{}
 			if harId != "" {
@@ -449,7 +467,7 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 			needCompressed := strings.HasSuffix(strings.ToLower(harMetaData.Path), ".zip")
 			if !b.connection.isRemote {
 				overrides["mode"] = "entries"
-				response, err := b.tracing.channel.SendReturnAsDict("harExport", overrides)
+				response, err := b.tracing.channel.within(ctx).SendReturnAsDict("harExport", overrides)
 				if err != nil {
 					return nil, err
 				}
@@ -460,7 +478,7 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 				if !ok {
 					return nil, fmt.Errorf("could not convert HAR entries: %v", response)
 				}
-				_, err = b.connection.LocalUtils().Zip(localUtilsZipOptions{
+				_, err = b.connection.LocalUtils().Zip(ctx, localUtilsZipOptions{
 					ZipFile: harMetaData.Path,
 					Entries: entries,
 					Mode:    "write",
@@ -471,7 +489,7 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 				continue
 			}
 			overrides["mode"] = "archive"
-			response, err := b.tracing.channel.SendReturnAsDict("harExport", overrides)
+			response, err := b.tracing.channel.within(ctx).SendReturnAsDict("harExport", overrides)
 			if err != nil {
 				return nil, err
 			}
@@ -479,32 +497,32 @@ func (b *browserContextImpl) Close(options ...BrowserContextCloseOptions) error
 			// Non-zip output is always unzipped into HAR JSON, regardless of the
 			// content policy (matching upstream _exportHAR which gates only on isZip).
 			if needCompressed {
-				if err := artifact.SaveAs(harMetaData.Path); err != nil {
+				if err := artifact.saveAs(ctx, harMetaData.Path); err != nil {
 					return nil, err
 				}
 			} else {
 				tmpPath := harMetaData.Path + ".tmp"
-				if err := artifact.SaveAs(tmpPath); err != nil {
+				if err := artifact.saveAs(ctx, tmpPath); err != nil {
 					return nil, err
 				}
-				err = b.connection.localUtils.HarUnzip(tmpPath, harMetaData.Path, harMetaData.ResourcesDir)
+				err = b.connection.localUtils.HarUnzip(ctx, tmpPath, harMetaData.Path, harMetaData.ResourcesDir)
 				if err != nil {
 					return nil, err
 				}
 			}
-			if err := artifact.Delete(); err != nil {
+			if _, err := artifact.channel.within(ctx).Send("delete"); err != nil {
 				return nil, err
 			}
 		}
 		return nil, nil
 	}
 
-	_, err = b.channel.connection.WrapAPICall(innerClose, true)
+	_, err = b.channel.connection.wrapAPICall(nil, "", nil, ctx, innerClose, true)
 	if err != nil {
 		return err
 	}
 
-	_, err = b.channel.Send("close", map[string]any{
+	_, err = b.channel.within(ctx).Send("close", map[string]any{
 		"reason": b.closeReason,
 	})
 	if err != nil {
@@ -790,68 +808,68 @@ func (b *browserContextImpl) ServiceWorkers() []Worker {
 	return b.serviceWorkers
 }
 
-func (b *browserContextImpl) OnBackgroundPage(fn func(Page)) {
-	b.On("backgroundpage", fn)
+func (b *browserContextImpl) OnBackgroundPage(fn func(Page)) Subscription {
+	return b.On("backgroundpage", fn)
 }
 
-func (b *browserContextImpl) OnClose(fn func(BrowserContext)) {
-	b.On("close", fn)
+func (b *browserContextImpl) OnClose(fn func(BrowserContext)) Subscription {
+	return b.On("close", fn)
 }
 
-func (b *browserContextImpl) OnConsole(fn func(ConsoleMessage)) {
-	b.On("console", fn)
+func (b *browserContextImpl) OnConsole(fn func(ConsoleMessage)) Subscription {
+	return b.On("console", fn)
 }
 
-func (b *browserContextImpl) OnDialog(fn func(Dialog)) {
-	b.On("dialog", fn)
+func (b *browserContextImpl) OnDialog(fn func(Dialog)) Subscription {
+	return b.On("dialog", fn)
 }
 
-func (b *browserContextImpl) OnDownload(fn func(Download)) {
-	b.On("download", fn)
+func (b *browserContextImpl) OnDownload(fn func(Download)) Subscription {
+	return b.On("download", fn)
 }
 
-func (b *browserContextImpl) OnFrameAttached(fn func(Frame)) {
-	b.On("frameattached", fn)
+func (b *browserContextImpl) OnFrameAttached(fn func(Frame)) Subscription {
+	return b.On("frameattached", fn)
 }
 
-func (b *browserContextImpl) OnFrameDetached(fn func(Frame)) {
-	b.On("framedetached", fn)
+func (b *browserContextImpl) OnFrameDetached(fn func(Frame)) Subscription {
+	return b.On("framedetached", fn)
 }
 
-func (b *browserContextImpl) OnFrameNavigated(fn func(Frame)) {
-	b.On("framenavigated", fn)
+func (b *browserContextImpl) OnFrameNavigated(fn func(Frame)) Subscription {
+	return b.On("framenavigated", fn)
 }
 
-func (b *browserContextImpl) OnPage(fn func(Page)) {
-	b.On("page", fn)
+func (b *browserContextImpl) OnPage(fn func(Page)) Subscription {
+	return b.On("page", fn)
 }
 
-func (b *browserContextImpl) OnPageClose(fn func(Page)) {
-	b.On("pageclose", fn)
+func (b *browserContextImpl) OnPageClose(fn func(Page)) Subscription {
+	return b.On("pageclose", fn)
 }
 
-func (b *browserContextImpl) OnPageLoad(fn func(Page)) {
-	b.On("pageload", fn)
+func (b *browserContextImpl) OnPageLoad(fn func(Page)) Subscription {
+	return b.On("pageload", fn)
 }
 
-func (b *browserContextImpl) OnWebError(fn func(WebError)) {
-	b.On("weberror", fn)
+func (b *browserContextImpl) OnWebError(fn func(WebError)) Subscription {
+	return b.On("weberror", fn)
 }
 
-func (b *browserContextImpl) OnRequest(fn func(Request)) {
-	b.On("request", fn)
+func (b *browserContextImpl) OnRequest(fn func(Request)) Subscription {
+	return b.On("request", fn)
 }
 
-func (b *browserContextImpl) OnRequestFailed(fn func(Request)) {
-	b.On("requestfailed", fn)
+func (b *browserContextImpl) OnRequestFailed(fn func(Request)) Subscription {
+	return b.On("requestfailed", fn)
 }
 
-func (b *browserContextImpl) OnRequestFinished(fn func(Request)) {
-	b.On("requestfinished", fn)
+func (b *browserContextImpl) OnRequestFinished(fn func(Request)) Subscription {
+	return b.On("requestfinished", fn)
 }
 
-func (b *browserContextImpl) OnResponse(fn func(Response)) {
-	b.On("response", fn)
+func (b *browserContextImpl) OnResponse(fn func(Response)) Subscription {
+	return b.On("response", fn)
 }
 
 func (b *browserContextImpl) RouteWebSocket(url any, handler func(WebSocketRoute)) error {
@@ -901,7 +919,7 @@ func (b *browserContextImpl) effectiveCloseReason() *string {
 }
 
 func newBrowserContext(parent *channelOwner, objectType string, guid string, initializer map[string]any) *browserContextImpl {
-	bt := &browserContextImpl{
+	bt := &browserContextImpl{browserContextState: &browserContextState{
 		timeoutSettings: newTimeoutSettings(nil),
 		pages:           make([]Page, 0),
 		backgroundPages: make([]Page, 0),
@@ -910,8 +928,9 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 		harRecorders:    make(map[string]harRecordingMetadata),
 		closed:          make(chan struct{}, 1),
 		harRouters:      make([]*harRouter, 0),
-	}
+	}}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
+	bt.channel = bt.channelOwner.channel
 	if parent.objectType == "Browser" {
 		bt.browser = fromChannel(parent.channel).(*browserImpl)
 		bt.browser.contexts = append(bt.browser.contexts, bt)
@@ -1078,9 +1097,9 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 			page.(*pageImpl).Emit("response", response)
 		}
 	})
-	bt.Once("close", func() {
+	subscribeInternal(bt, "close", func() {
 		bt.closed <- struct{}{}
-	})
+	}, true)
 	bt.setEventSubscriptionMapping(map[string]string{
 		"console":         "console",
 		"dialog":          "dialog",
@@ -0,0 +1,236 @@
+package playwright
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// A BrowserServer is created via [BrowserType.LaunchServer]. Other processes
+// attach to the browser it hosts with [BrowserType.Connect] or [Connect] at
+// [BrowserServer.WSEndpoint].
+type BrowserServer interface {
+	// Closes the browser gracefully and makes sure the process is terminated.
+	Close() error
+
+	// Kills the browser process and waits for the process to exit.
+	Kill() error
+
+	// Returns the process hosting the server: the driver's `launch-server`
+	// command, which the browser runs under. The browser exits with it.
+	Process() *os.Process
+
+	// Browser websocket endpoint which can be used as an argument to
+	// [BrowserType.Connect] to establish connection to the browser.
+	WSEndpoint() string
+}
+
+// BrowserServerLauncher is implemented by [BrowserType].
+type BrowserServerLauncher interface {
+	// Returns the browser app instance. You can connect to it via [BrowserType.Connect], which requires the major/minor
+	// client/server version to match (https://playwright.dev/docs/test-runners#client-server-versions).
+	LaunchServer(options ...BrowserTypeLaunchServerOptions) (BrowserServer, error)
+}
+
+type BrowserTypeLaunchServerOptions struct {
+	// **NOTE** Use custom browser args at your own risk, as some of them may break Playwright functionality.
+	// Additional arguments to pass to the browser instance. The list of Chromium flags can be found
+	// [here].
+	//
+	// [here]: https://peter.sh/experiments/chromium-command-line-switches/
+	Args []string `json:"args"`
+	// Browser distribution channel.
+	// Use "chromium" to [opt in to new headless mode].
+	// Use "chrome", "chrome-beta", "chrome-dev", "chrome-canary", "msedge", "msedge-beta", "msedge-dev", or
+	// "msedge-canary" to use branded [Google Chrome and Microsoft Edge].
+	//
+	// [opt in to new headless mode]: https://playwright.dev/docs/browsers#chromium-new-headless-mode
+	// [Google Chrome and Microsoft Edge]: https://playwright.dev/docs/browsers#google-chrome--microsoft-edge
+	Channel *string `json:"channel"`
+	// Enable Chromium sandboxing. Defaults to `false`.
+	ChromiumSandbox *bool `json:"chromiumSandbox"`
+	// If specified, accepted downloads are downloaded into this directory. Otherwise, temporary directory is created and
+	// is deleted when browser is closed. In either case, the downloads are deleted when the browser context they were
+	// created in is closed.
+	DownloadsPath *string `json:"downloadsPath"`
+	// Specify environment variables that will be visible to the browser. Defaults to `process.env`.
+	Env map[string]string `json:"env"`
+	// Path to a browser executable to run instead of the bundled one. If ExecutablePath is a relative path, then it is
+	// resolved relative to the current working directory. Note that Playwright only works with the bundled Chromium,
+	// Firefox or WebKit, use at your own risk.
+	ExecutablePath *string `json:"executablePath"`
+	// Firefox user preferences. Learn more about the Firefox user preferences at
+	// [`about:config`].
+	//
+	// [`about:config`]: https://support.mozilla.org/en-US/kb/about-config-editor-firefox
+	FirefoxUserPrefs map[string]any `json:"firefoxUserPrefs"`
+	// Whether to run browser in headless mode. More details for
+	// [Chromium] and
+	// [Firefox]. Defaults to `true`.
+	//
+	// [Chromium]: https://developers.google.com/web/updates/2017/04/headless-chrome
+	// [Firefox]: https://hacks.mozilla.org/2017/12/using-headless-mode-in-firefox/
+	Headless *bool `json:"headless"`
+	// Host to use for the web socket. It is optional and if it is omitted, the server will accept connections on the
+	// unspecified IPv6 address (::) when IPv6 is available, or the unspecified IPv4 address (0.0.0.0) otherwise. Consider
+	// hardening it with picking a specific interface.
+	Host *string `json:"host"`
+	// If `true`, Playwright does not pass its own configurations args and only uses the ones from Args.
+	// Dangerous option; use with care. Defaults to `false`.
+	IgnoreAllDefaultArgs *bool `json:"ignoreAllDefaultArgs"`
+	// If `true`, Playwright does not pass its own configurations args and only uses the ones from Args.
+	// Dangerous option; use with care.
+	IgnoreDefaultArgs []string `json:"ignoreDefaultArgs"`
+	// Port to use for the web socket. Defaults to 0 that picks any available port.
+	Port *int `json:"port"`
+	// Network proxy settings.
+	Proxy *Proxy `json:"proxy"`
+	// Maximum time in milliseconds to wait for the browser instance to start. Defaults to `30000` (30 seconds). Pass `0`
+	// to disable timeout.
+	Timeout *float64 `json:"timeout"`
+	// If specified, traces are saved into this directory.
+	TracesDir *string `json:"tracesDir"`
+	// Path at which to serve the Browser Server. For security, this defaults to an unguessable string.
+	// **NOTE** Any process or web page (including those running in Playwright) with knowledge of the `wsPath` can take
+	// control of the OS user. For this reason, you should use an unguessable token when using this option.
+	WsPath *string `json:"wsPath"`
+}
+
+// browserServerCloseTimeout bounds how long Close waits for the browser to
+// shut down gracefully before killing it.
+const browserServerCloseTimeout = 10 * time.Second
+
+type browserServerImpl struct {
+	process    *os.Process
+	wsEndpoint string
+	exited     chan struct{}
+}
+
+func (b *browserTypeImpl) LaunchServer(options ...BrowserTypeLaunchServerOptions) (BrowserServer, error) {
+	// Like Pid, this needs the driver process of a local instance.
+	pt, ok := b.connection.transport.(*pipeTransport)
+	if !ok || pt.driver == nil {
+		return nil, fmt.Errorf("%w: LaunchServer needs a Playwright instance started with Run", ErrPlaywright)
+	}
+	return launchBrowserServer(pt.driver, b.Name(), options...)
+}
+
+// launchBrowserServer runs `launch-server` of driver, which passes the options
+// read from a config file to upstream's browserType.launchServer and prints
+// the endpoint of the server.
+func launchBrowserServer(driver *PlaywrightDriver, browserName string, options ...BrowserTypeLaunchServerOptions) (*browserServerImpl, error) {
+	config, err := launchServerConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+	configFile, err := os.CreateTemp("", "playwright-go-launch-server-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("could not write launch server options: %w", err)
+	}
+	defer os.Remove(configFile.Name()) //nolint:errcheck
+	_, err = configFile.Write(config)
+	if closeErr := configFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not write launch server options: %w", err)
+	}
+
+	cmd := driver.Command("launch-server", "--browser", browserName, "--config", configFile.Name())
+	stderr := &stderrTail{w: driver.options.Stderr}
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("could not create stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("could not start browser server: %w", err)
+	}
+	server := &browserServerImpl{process: cmd.Process, exited: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		close(server.exited)
+	}()
+
+	reader := bufio.NewReader(stdout)
+	line, err := reader.ReadString('\n')
+	server.wsEndpoint = strings.TrimSpace(line)
+	if err != nil || !strings.HasPrefix(server.wsEndpoint, "ws") {
+		_ = server.Kill()
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			return nil, fmt.Errorf("could not launch browser server: %s", output)
+		}
+		return nil, fmt.Errorf("could not launch browser server: unexpected output %q", line)
+	}
+	// Keep the pipe drained so that later output does not block the server.
+	go io.Copy(io.Discard, reader) //nolint:errcheck
+	return server, nil
+}
+
+// launchServerConfig serializes options for upstream's launchServer, which
+// tells absent options from null ones, so nulls are left out.
+func launchServerConfig(options ...BrowserTypeLaunchServerOptions) ([]byte, error) {
+	config := map[string]any{}
+	if len(options) == 1 {
+		data, err := json.Marshal(options[0])
+		if err != nil {
+			return nil, fmt.Errorf("could not serialize launch server options: %w", err)
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("could not serialize launch server options: %w", err)
+		}
+		removeNulls(config)
+		if ignoreAll, ok := config["ignoreAllDefaultArgs"]; ok {
+			delete(config, "ignoreAllDefaultArgs")
+			if ignoreAll == true {
+				config["ignoreDefaultArgs"] = true
+			}
+		}
+	}
+	return json.Marshal(config)
+}
+
+func removeNulls(value map[string]any) {
+	for key, v := range value {
+		switch v := v.(type) {
+		case nil:
+			delete(value, key)
+		case map[string]any:
+			removeNulls(v)
+		}
+	}
+}
+
+func (s *browserServerImpl) WSEndpoint() string {
+	return s.wsEndpoint
+}
+
+func (s *browserServerImpl) Process() *os.Process {
+	return s.process
+}
+
+func (s *browserServerImpl) Close() error {
+	if err := interruptProcess(s.process); err != nil {
+		return s.Kill()
+	}
+	select {
+	case <-s.exited:
+		return nil
+	case <-time.After(browserServerCloseTimeout):
+		return s.Kill()
+	}
+}
+
+func (s *browserServerImpl) Kill() error {
+	if err := s.process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("could not kill browser server: %w", err)
+	}
+	<-s.exited
+	return nil
+}
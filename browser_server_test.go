@@ -0,0 +1,55 @@
+package playwright
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchBrowserServer(t *testing.T) {
+	dir := t.TempDir()
+	driver := newFakeDriver(t, `
+echo "$2 $3 $4 $5" > `+dir+`/args
+cp "$6" `+dir+`/config.json
+trap 'echo closed > `+dir+`/closed; exit 0' TERM
+echo ws://127.0.0.1:4444/secret
+while true; do sleep 0.1; done
+`)
+	server, err := launchBrowserServer(driver, "firefox", BrowserTypeLaunchServerOptions{
+		Headless:             Bool(false),
+		Port:                 Int(4444),
+		IgnoreAllDefaultArgs: Bool(true),
+		Proxy:                &Proxy{Server: "http://proxy:3128"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ws://127.0.0.1:4444/secret", server.WSEndpoint())
+	require.NotZero(t, server.Process().Pid)
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	require.Equal(t, "launch-server --browser firefox --config\n", string(args))
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	var config map[string]any
+	require.NoError(t, json.Unmarshal(data, &config))
+	require.Equal(t, map[string]any{
+		"headless":          false,
+		"port":              float64(4444),
+		"ignoreDefaultArgs": true,
+		"proxy":             map[string]any{"server": "http://proxy:3128"},
+	}, config)
+
+	require.NoError(t, server.Close())
+	require.FileExists(t, filepath.Join(dir, "closed"))
+	require.NoError(t, server.Close())
+	require.NoError(t, server.Kill())
+}
+
+func TestLaunchBrowserServerFails(t *testing.T) {
+	driver := newFakeDriver(t, "echo 'Error: Executable does not exist' >&2\nexit 1\n")
+	_, err := launchBrowserServer(driver, "chromium")
+	require.ErrorContains(t, err, "could not launch browser server: Error: Executable does not exist")
+}
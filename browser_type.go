@@ -134,6 +134,55 @@ func (b *browserTypeImpl) LaunchPersistentContext(userDataDir string, options ..
 }
 
 func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConnectOptions) (Browser, error) {
+	if !b.connection.nativeConnect || len(options) == 1 && options[0].ExposeNetwork != nil {
+		// Exposing the client network needs the driver's SOCKS interceptor.
+		return b.connectThroughDriver(wsEndpoint, options...)
+	}
+	transport, err := dialWebSocketTransport(wsEndpoint, b.Name(), options...)
+	if err != nil {
+		return nil, err
+	}
+	// The local driver still provides LocalUtils (HAR, tracing, zip) to the
+	// remote connection.
+	connection := newConnection(transport, b.connection.LocalUtils())
+	connection.protocolLog = b.connection.protocolLog
+	connection.instrumentation = b.connection.instrumentation
+	connection.onHandleLeak = b.connection.onHandleLeak
+	connection.asyncEvents = b.connection.asyncEvents
+	browser, err := connectBrowser(connection, b)
+	if err != nil {
+		return nil, err
+	}
+	connection.onDisconnected(func(error) { closeConnectedBrowser(browser) })
+	return browser, nil
+}
+
+// Connect connects to a browser server like [BrowserType.Connect], but without
+// a local driver, so that no Node.js is needed. browserName ("chromium",
+// "firefox" or "webkit") selects the browser a `playwright run-server` launches;
+// servers started with [BrowserType.LaunchServer] ignore it.
+//
+// Features that rely on the driver's local utilities are limited: routing from
+// a HAR file is unavailable, traces are saved without source code stacks, and
+// ExposeNetwork is not supported.
+func Connect(browserName, wsEndpoint string, options ...BrowserTypeConnectOptions) (Browser, error) {
+	transport, err := dialWebSocketTransport(wsEndpoint, browserName, options...)
+	if err != nil {
+		return nil, err
+	}
+	connection := newConnection(transport)
+	connection.isRemote = true
+	browser, err := connectBrowser(connection, nil)
+	if err != nil {
+		return nil, err
+	}
+	connection.onDisconnected(func(error) { closeConnectedBrowser(browser) })
+	return browser, nil
+}
+
+// connectThroughDriver connects with the driver's LocalUtils.connect, which
+// relays the WebSocket through a jsonPipe.
+func (b *browserTypeImpl) connectThroughDriver(wsEndpoint string, options ...BrowserTypeConnectOptions) (Browser, error) {
 	overrides := map[string]any{
 		"endpoint": wsEndpoint,
 		"headers": map[string]string{
@@ -159,33 +208,53 @@ func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConne
 	}
 	jsonPipe := fromChannel(pipe["pipe"]).(*jsonPipe)
 	connection := newConnection(jsonPipe, localUtils)
+	connection.protocolLog = b.connection.protocolLog
+	connection.instrumentation = b.connection.instrumentation
+	connection.onHandleLeak = b.connection.onHandleLeak
+	connection.asyncEvents = b.connection.asyncEvents
+	browser, err := connectBrowser(connection, b)
+	if err != nil {
+		return nil, err
+	}
+	subscribeInternal(jsonPipe, "closed", func() {
+		closeConnectedBrowser(browser)
+		connection.cleanup()
+	}, false)
+	return browser, nil
+}
 
+// connectBrowser starts a connection to a browser server and returns the
+// browser it serves. browserType is the local BrowserType the browser is
+// attributed to, or nil to use the remote one.
+func connectBrowser(connection *connection, browserType *browserTypeImpl) (*browserImpl, error) {
 	playwright, err := connection.Start()
 	if err != nil {
+		_ = connection.transport.Close()
 		return nil, err
 	}
-	playwright.setSelectors(b.playwright.Selectors)
+	if browserType != nil {
+		playwright.setSelectors(browserType.playwright.Selectors)
+	}
 	preLaunchedBrowser := fromNullableChannel(playwright.initializer["preLaunchedBrowser"])
 	if preLaunchedBrowser == nil {
+		_ = connection.transport.Close()
 		connection.cleanup()
 		return nil, errors.New("malformed endpoint. Did you use BrowserType.LaunchServer method?")
 	}
 	browser := preLaunchedBrowser.(*browserImpl)
 	browser.shouldCloseConnectionOnClose = true
-	pipeClosed := func() {
-		for _, context := range browser.Contexts() {
-			pages := context.Pages()
-			for _, page := range pages {
-				page.(*pageImpl).onClose()
+	if browserType == nil {
+		for _, remote := range []BrowserType{playwright.Chromium, playwright.Firefox, playwright.WebKit} {
+			if remote.(*browserTypeImpl).guid == browser.parent.guid {
+				browserType = remote.(*browserTypeImpl)
 			}
-			context.(*browserContextImpl).onClose()
 		}
-		browser.onClose()
-		connection.cleanup()
+		if browserType == nil {
+			browserType = browser.browserType.(*browserTypeImpl)
+		}
 	}
-	jsonPipe.On("closed", pipeClosed)
 
-	b.didLaunchBrowser(browser)
+	browserType.didLaunchBrowser(browser)
 	// When connecting to a shared browser server, the browser's pre-existing
 	// contexts are dispatched before didLaunchBrowser wires the real browserType
 	// (whose playwright is non-nil), so newBrowserContext's own selectors
@@ -193,11 +262,24 @@ func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConne
 	// _connectToBrowserType -> _setupBrowserContext loop over all existing
 	// contexts, so custom selector engines / testId reach pre-existing contexts.
 	for _, context := range browser.Contexts() {
-		b.registerContextSelectors(context.(*browserContextImpl))
+		browserType.registerContextSelectors(context.(*browserContextImpl))
 	}
 	return browser, nil
 }
 
+// closeConnectedBrowser closes the objects of a connected browser once its
+// connection is gone.
+func closeConnectedBrowser(browser *browserImpl) {
+	for _, context := range browser.Contexts() {
+		pages := context.Pages()
+		for _, page := range pages {
+			page.(*pageImpl).onClose()
+		}
+		context.(*browserContextImpl).onClose()
+	}
+	browser.onClose()
+}
+
 func (b *browserTypeImpl) ConnectOverCDP(endpointURL string, options ...BrowserTypeConnectOverCDPOptions) (Browser, error) {
 	if b.Name() != "chromium" {
 		return nil, errors.New("connecting over CDP is only supported in Chromium")
@@ -9,8 +9,8 @@ func (c *cdpSessionImpl) Detach() error {
 	return err
 }
 
-func (c *cdpSessionImpl) OnClose(fn func(CDPSession)) {
-	c.On("close", fn)
+func (c *cdpSessionImpl) OnClose(fn func(CDPSession)) Subscription {
+	return c.On("close", fn)
 }
 
 func (c *cdpSessionImpl) Send(method string, params map[string]any) (any, error) {
@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -9,8 +10,21 @@ type channel struct {
 	eventEmitter
 	guid       string
 	connection *connection
-	owner      *channelOwner // to avoid type conversion
-	object     any           // retain type info (for fromChannel needed)
+	owner      *channelOwner   // to avoid type conversion
+	object     any             // retain type info (for fromChannel needed)
+	ctx        context.Context // bound by WithContext, if any
+}
+
+// withContext returns a channel of the same object whose calls are bound to
+// ctx. It only sends; events are emitted on the original channel.
+func (c *channel) withContext(ctx context.Context) *channel {
+	return &channel{
+		guid:       c.guid,
+		connection: c.connection,
+		owner:      c.owner,
+		object:     c.object,
+		ctx:        ctx,
+	}
 }
 
 func (c *channel) MarshalJSON() ([]byte, error) {
@@ -37,8 +51,9 @@ func (c *channel) CreateTask(fn func()) {
 }
 
 func (c *channel) Send(method string, options ...any) (any, error) {
-	return c.connection.WrapAPICall(func() (any, error) {
-		result, err := c.innerSend(method, options...).GetResultValue()
+	params := transformOptions(options...)
+	return c.connection.wrapAPICall(c, method, params, c.ctx, func(ctx context.Context) (any, error) {
+		result, err := c.innerSend(ctx, method, params).GetResultValue()
 		if err != nil {
 			return nil, err
 		}
@@ -48,8 +63,9 @@ func (c *channel) Send(method string, options ...any) (any, error) {
 }
 
 func (c *channel) SendReturnAsDict(method string, options ...any) (map[string]any, error) {
-	ret, err := c.connection.WrapAPICall(func() (any, error) {
-		result, err := c.innerSend(method, options...).GetResult()
+	params := transformOptions(options...)
+	ret, err := c.connection.wrapAPICall(c, method, params, c.ctx, func(ctx context.Context) (any, error) {
+		result, err := c.innerSend(ctx, method, params).GetResult()
 		if err != nil {
 			return nil, err
 		}
@@ -65,15 +81,33 @@ func (c *channel) SendReturnAsDict(method string, options ...any) (map[string]an
 	return ret.(map[string]any), nil
 }
 
-func (c *channel) innerSend(method string, options ...any) *protocolCallback {
+// instrumentAPICall makes fn a single API call on the channel's object for the
+// Instrumentation. The calls fn makes with ctx, on channels from within(ctx),
+// are part of it, however many there are.
+func (c *channel) instrumentAPICall(fn func(ctx context.Context) error) error {
+	_, err := c.connection.instrumentAPICall(c, "", nil, c.ctx, nil, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	}, c.owner.isInternalType)
+	return err
+}
+
+// within returns the channel to make a call that is part of the API call of
+// ctx, as given by instrumentAPICall.
+func (c *channel) within(ctx context.Context) *channel {
+	if ctx == nil {
+		return c
+	}
+	return c.withContext(ctx)
+}
+
+func (c *channel) innerSend(ctx context.Context, method string, params map[string]any) *protocolCallback {
 	if err := c.connection.err.Get(); err != nil {
 		c.connection.err.Set(nil)
 		pc := newProtocolCallback(c.connection, false, c.connection.abort)
 		pc.SetError(err)
 		return pc
 	}
-	params := transformOptions(options...)
-	return c.connection.sendMessageToServer(c.owner, method, params, false)
+	return c.connection.sendMessageToServer(ctx, c.owner, method, params, false)
 }
 
 // SendNoReply ignores return value and errors
@@ -88,8 +122,8 @@ func (c *channel) SendNoReplyInternal(method string, options ...any) {
 
 func (c *channel) innerSendNoReply(method string, isInternal bool, options ...any) {
 	params := transformOptions(options...)
-	_, err := c.connection.WrapAPICall(func() (any, error) {
-		return c.connection.sendMessageToServer(c.owner, method, params, true).GetResult()
+	_, err := c.connection.wrapAPICall(c, method, params, c.ctx, func(ctx context.Context) (any, error) {
+		return c.connection.sendMessageToServer(ctx, c.owner, method, params, true).GetResult()
 	}, isInternal)
 	if err != nil {
 		// ignore error actively, log only for debug
@@ -17,6 +17,11 @@ type channelOwner struct {
 	parent                     *channelOwner
 	wasCollected               bool
 	isInternalType             bool
+	// created orders the objects of the connection by creation.
+	created uint64
+	// handlers queues the event handlers when they run asynchronously.
+	handlers     *handlerQueue
+	handlersOnce sync.Once
 }
 
 func (c *channelOwner) dispose(reason ...string) {
@@ -56,19 +61,55 @@ func (c *channelOwner) updateSubscription(event string, enabled bool) {
 	}
 }
 
-func (c *channelOwner) Once(name string, handler any) {
-	c.addEvent(name, handler, true)
+func (c *channelOwner) Once(name string, handler any) Subscription {
+	return c.subscribe(name, listener{handler: handler, once: true})
 }
 
-func (c *channelOwner) On(name string, handler any) {
-	c.addEvent(name, handler, false)
+func (c *channelOwner) On(name string, handler any) Subscription {
+	return c.subscribe(name, listener{handler: handler})
 }
 
-func (c *channelOwner) addEvent(name string, handler any, once bool) {
+func (c *channelOwner) subscribe(name string, l listener) *subscription {
 	if c.ListenerCount(name) == 0 {
 		c.updateSubscription(name, true)
 	}
-	c.eventEmitter.addEvent(name, handler, once)
+	sub := c.eventEmitter.subscribe(name, l)
+	remove := sub.remove
+	sub.remove = func() {
+		remove()
+		if c.ListenerCount(name) == 0 {
+			c.updateSubscription(name, false)
+		}
+	}
+	return sub
+}
+
+// Emit calls the handlers of the event. The handlers of users are deferred
+// when the event is emitted while a message is dispatched, see
+// connection.receiveLoop, or queued when the connection runs handlers
+// asynchronously; those of playwright-go itself always run right away. It
+// reports whether handlers are called, which is not the case for a dropped
+// event.
+func (c *channelOwner) Emit(name string, payload ...any) bool {
+	if c.connection == nil || (c.connection.asyncEvents == nil && !c.connection.dispatching.Load()) {
+		return c.eventEmitter.Emit(name, payload...)
+	}
+	called := c.emit(name, isInternalListener, payload...) > 0
+	if c.listenerCount(name, isUserListener) == 0 {
+		return called
+	}
+	if c.connection.asyncEvents == nil {
+		c.connection.deferHandler(func() { c.emit(name, isUserListener, payload...) })
+		return true
+	}
+	c.handlersOnce.Do(func() {
+		c.handlers = newHandlerQueue(*c.connection.asyncEvents)
+	})
+	queued, dropped := c.handlers.push(func() { c.emit(name, isUserListener, payload...) }, keptEvents[name])
+	if dropped {
+		logger.Warn("event handler queue is full, dropped an event", "objectType", c.objectType, "guid", c.guid, "event", name)
+	}
+	return called || queued
 }
 
 func (c *channelOwner) RemoveListener(name string, handler any) {
@@ -82,15 +123,23 @@ func (c *channelOwner) createChannelOwner(self any, parent *channelOwner, object
 	c.objectType = objectType
 	c.guid = guid
 	c.wasCollected = false
-	c.parent = parent
 	c.objects = make(map[string]*channelOwner)
 	c.initializer = initializer
-	if c.parent != nil {
+	if parent != nil {
 		c.connection = parent.connection
+	}
+	if c.connection != nil {
+		c.connection.objectsMu.Lock()
+	}
+	c.parent = parent
+	if c.parent != nil {
 		c.parent.objects[guid] = c
 	}
 	if c.connection != nil {
+		c.connection.createdObjects++
+		c.created = c.connection.createdObjects
 		c.connection.objects.Store(guid, c)
+		c.connection.objectsMu.Unlock()
 	}
 	c.channel = newChannel(c, self)
 	c.eventToSubscriptionMapping = map[string]string{}
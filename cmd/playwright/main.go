@@ -1,21 +1,46 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/mxschmitt/playwright-go"
 )
 
 func main() {
-	driver, err := playwright.NewDriver(&playwright.RunOptions{})
+	args := os.Args[1:]
+	options := &playwright.RunOptions{}
+	if len(args) > 0 {
+		switch args[0] {
+		case "bundle":
+			runBundle(args[1:])
+			return
+		case "cache":
+			runCache(args[1:])
+			return
+		case "doctor":
+			runDoctor(args[1:])
+			return
+		case "install":
+			// --from is handled here rather than by the Node.js CLI: it provides
+			// the driver itself, so it has to be applied before the driver runs.
+			args, options.DriverBundle = extractFlag(args, "from")
+		}
+	}
+
+	driver, err := playwright.NewDriver(options)
 	if err != nil {
 		log.Fatalf("could not start driver: %v", err)
 	}
 	if err = driver.DownloadDriver(); err != nil {
 		log.Fatalf("could not download driver: %v", err)
 	}
-	cmd := driver.Command(os.Args[1:]...)
+	cmd := driver.Command(args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -23,3 +48,162 @@ func main() {
 	}
 	os.Exit(cmd.ProcessState.ExitCode())
 }
+
+// runBundle implements `bundle [--platform GOOS/GOARCH] <output dir|file.tar>`,
+// which downloads a driver bundle for `install --from`.
+func runBundle(args []string) {
+	flags := flag.NewFlagSet("bundle", flag.ExitOnError)
+	platform := flags.String("platform", "", "target platform as GOOS/GOARCH (default: current platform)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: playwright bundle [--platform GOOS/GOARCH] <output dir|file.tar>") //nolint:errcheck
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	driver, err := playwright.NewDriver(&playwright.RunOptions{Verbose: true})
+	if err != nil {
+		log.Fatalf("could not start driver: %v", err)
+	}
+	if err := driver.BundleDriver(flags.Arg(0), *platform); err != nil {
+		log.Fatalf("could not bundle driver: %v", err)
+	}
+}
+
+// runCache implements `cache ls` and `cache prune [keep-version...]`.
+func runCache(args []string) {
+	if len(args) == 0 || (args[0] != "ls" && args[0] != "prune") {
+		fmt.Fprintln(os.Stderr, "Usage: playwright cache ls|prune [keep-version...]") //nolint:errcheck
+		os.Exit(2)
+	}
+	driver, err := playwright.NewDriver(&playwright.RunOptions{})
+	if err != nil {
+		log.Fatalf("could not start driver: %v", err)
+	}
+	if args[0] == "prune" {
+		removed, err := driver.Prune(args[1:]...)
+		for _, path := range removed {
+			fmt.Printf("removed %s\n", path)
+		}
+		if err != nil {
+			log.Fatalf("could not prune cache: %v", err)
+		}
+		return
+	}
+
+	drivers, err := driver.ListCachedDrivers()
+	if err != nil {
+		log.Fatalf("could not list drivers: %v", err)
+	}
+	browsers, err := driver.InstalledBrowsers()
+	if err != nil {
+		log.Fatalf("could not list browsers: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tVERSION\tSIZE\tIN USE\tPATH") //nolint:errcheck
+	for _, d := range drivers {
+		fmt.Fprintf(w, "driver\tplaywright-core\t%s\t%s\t%t\t%s\n", d.Version, formatSize(d.Size), d.Current, d.Path) //nolint:errcheck
+	}
+	for _, b := range browsers {
+		fmt.Fprintf(w, "browser\t%s\t%s\t%s\t%t\t%s\n", b.Name, b.Revision, formatSize(b.Size), b.Referenced, b.Path) //nolint:errcheck
+	}
+	_ = w.Flush()
+}
+
+// runDoctor implements `doctor [--json]`. It exits with status 1 when it finds
+// problems.
+func runDoctor(args []string) {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	_ = flags.Parse(args)
+	driver, err := playwright.NewDriver(&playwright.RunOptions{})
+	if err != nil {
+		log.Fatalf("could not start driver: %v", err)
+	}
+	report := driver.Diagnose()
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("could not encode report: %v", err)
+		}
+	} else {
+		printDiagnostics(report)
+	}
+	if len(report.Problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printDiagnostics(report *playwright.Diagnostics) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Platform:\t%s\n", report.Platform)                                                //nolint:errcheck
+	fmt.Fprintf(w, "Driver:\t%s (installed: %t)\n", report.Driver.Directory, report.Driver.Installed) //nolint:errcheck
+	fmt.Fprintf(w, "Driver version:\t%s\n", report.Driver.Version)                                    //nolint:errcheck
+	fmt.Fprintf(w, "Driver patch:\t%s\n", report.Driver.PatchStatus)                                  //nolint:errcheck
+	node := report.Node.Version
+	if report.Node.Error != "" {
+		node = "error: " + report.Node.Error
+	}
+	fmt.Fprintf(w, "Node.js:\t%s (%s) %s\n", report.Node.Path, report.Node.Source, node) //nolint:errcheck
+	if report.Sandbox != nil {
+		fmt.Fprintf(w, "User namespaces:\t%t %s\n", report.Sandbox.UserNamespaces, report.Sandbox.Detail) //nolint:errcheck
+	}
+	for _, cache := range report.Cache {
+		fmt.Fprintf(w, "Cache:\t%s (exists: %t, writable: %t)\n", cache.Path, cache.Exists, cache.Writable) //nolint:errcheck
+	}
+	for _, browser := range report.Browsers {
+		status := "ok"
+		if browser.Error != "" {
+			status = "error: " + browser.Error
+		} else if len(browser.MissingLibraries) > 0 {
+			status = "missing " + strings.Join(browser.MissingLibraries, ", ")
+		}
+		fmt.Fprintf(w, "Browser:\t%s %s (in use: %t) %s\n", browser.Name, browser.Revision, browser.Referenced, status) //nolint:errcheck
+	}
+	_ = w.Flush()
+	if len(report.Problems) == 0 {
+		fmt.Println("\nNo problems found.")
+		return
+	}
+	fmt.Println("\nProblems:")
+	for _, problem := range report.Problems {
+		fmt.Printf("  - %s\n", problem)
+	}
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// extractFlag removes "--name value" or "--name=value" from args and returns
+// the remaining args along with the value.
+func extractFlag(args []string, name string) ([]string, string) {
+	rest := make([]string, 0, len(args))
+	value := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if v, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			value = v
+			continue
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			value = args[i+1]
+			i++
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, value
+}
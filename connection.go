@@ -1,10 +1,13 @@
 package playwright
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,10 +19,24 @@ import (
 )
 
 var (
-	pkgSourcePathPattern = regexp.MustCompile(`.+[\\/]playwright-go[\\/][^\\/]+\.go`)
-	apiNameTransform     = regexp.MustCompile(`(?U)\(\*(.+)(Impl)?\)`)
+	pkgSourceDir     = packageSourceDir()
+	apiNameTransform = regexp.MustCompile(`(?U)\(\*(.+)(Impl)?\)`)
 )
 
+// packageSourceDir returns the directory of the source files of the package
+// as it appears in stack frames, which holds for a checkout, the module cache
+// and -trimpath builds alike.
+func packageSourceDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}
+
+// isInternalFrame reports whether file is a source file of the package, not
+// counting its tests.
+func isInternalFrame(file string) bool {
+	return path.Dir(file) == pkgSourceDir && !strings.HasSuffix(file, "_test.go")
+}
+
 type connection struct {
 	transport    transport
 	apiZone      sync.Map
@@ -36,27 +53,46 @@ type connection struct {
 	abortOnce    sync.Once
 	err          *safeValue[error] // for event listener error
 	closedError  *safeValue[error]
-
-	// dispatchGID is the id of the goroutine that runs the receive loop (and
-	// therefore synchronously runs event handlers). When a handler makes a
-	// blocking server call, the reply can only be delivered by this same
-	// goroutine; blocking on it would deadlock. waitResult detects that case by
-	// comparing against this id and instead pumps the receive loop re-entrantly
-	// until the reply arrives. Keeping all message processing on one goroutine
-	// preserves the original event ordering and avoids data races on the object
-	// graph.
-	dispatchGID atomic.Uint64
+	stopping     atomic.Bool // set by Stop, to tell a requested close from a crash
+	protocolLog  *protocolLogger
+	// instrumentation is RunOptions.Instrumentation, or nil.
+	instrumentation Instrumentation
+	// onHandleLeak is RunOptions.OnHandleLeak, or nil.
+	onHandleLeak func(HandleLeak)
+	// asyncEvents is RunOptions.AsyncEvents, or nil to run event handlers on
+	// the receive loop.
+	asyncEvents *AsyncEventOptions
+	// nativeConnect is RunOptions.NativeConnect.
+	nativeConnect bool
+	// objectsMu guards the parent and children of the objects, which the
+	// receive loop changes, so they can be inspected from other goroutines.
+	objectsMu sync.RWMutex
+	// createdObjects counts the objects created so far, guarded by objectsMu.
+	createdObjects uint64
+
+	disconnectedMu       sync.Mutex
+	disconnectedHandlers []func(error)
+	disconnected         bool
+
+	// reader is held by the goroutine that reads and dispatches messages: the
+	// receive loop, or a call made by an event handler while it waits for its
+	// reply, see waitResult.
+	reader chan struct{}
+	// dispatching is set while the holder of reader dispatches an event or
+	// object lifecycle message. The handlers of users that Emit calls then are
+	// deferred to pending rather than run within the dispatch.
+	dispatching atomic.Bool
+	// handling is set while the receive loop runs the functions of pending,
+	// i.e. event handlers, and so does not read messages.
+	handling  atomic.Bool
+	pendingMu sync.Mutex
+	// pending are the event handlers deferred by Emit, and the replies
+	// received after them, in the order the receive loop runs them.
+	pending []func()
 }
 
 func (c *connection) Start() (*Playwright, error) {
-	go func() {
-		c.dispatchGID.Store(currentGoroutineID())
-		for {
-			if !c.pollOnce() {
-				return
-			}
-		}
-	}()
+	go c.receiveLoop()
 
 	c.onClose = func() error {
 		if err := c.transport.Close(); err != nil {
@@ -68,22 +104,98 @@ func (c *connection) Start() (*Playwright, error) {
 	return c.rootObject.initialize()
 }
 
-// pollOnce reads and dispatches a single message. It returns false when the
-// transport is closed or errors, signalling the receive loop to stop. It is
-// also called re-entrantly from waitResult to drain replies for a blocking
-// call made on the dispatch goroutine itself (see waitResult).
+// receiveLoop reads and dispatches messages until the transport is closed,
+// and runs the event handlers deferred by their dispatch in between. While it
+// runs handlers, the calls they make read their replies themselves, see
+// waitResult.
+func (c *connection) receiveLoop() {
+	defer c.runPending()
+	for {
+		c.runPending()
+		c.reader <- struct{}{}
+		// A call that read messages while handlers ran may have deferred more.
+		if c.hasPending() {
+			<-c.reader
+			continue
+		}
+		ok := c.pollOnce()
+		<-c.reader
+		if !ok {
+			return
+		}
+	}
+}
+
+// pollOnce reads and dispatches a single message. It must be called with
+// reader held. It returns false when the transport is closed or errors,
+// signalling the receive loop to stop.
 func (c *connection) pollOnce() bool {
+	select {
+	case <-c.abort:
+		return false
+	default:
+	}
 	msg, err := c.transport.Poll()
 	if err != nil {
 		_ = c.transport.Close()
 		c.cleanup(err)
 		return false
 	}
+	if msg.ID == 0 {
+		// The handlers of internal listeners, like waiters, run right away, so
+		// the code run within the dispatch must not make blocking calls.
+		c.dispatching.Store(true)
+		defer c.dispatching.Store(false)
+	}
 	c.Dispatch(msg)
 	return true
 }
 
+// deferHandler queues the event handlers fn for the receive loop.
+func (c *connection) deferHandler(fn func()) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	c.pending = append(c.pending, fn)
+}
+
+// deferReply queues deliver behind the pending event handlers, and reports
+// whether there were any.
+func (c *connection) deferReply(deliver func()) bool {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if len(c.pending) == 0 {
+		return false
+	}
+	c.pending = append(c.pending, deliver)
+	return true
+}
+
+func (c *connection) hasPending() bool {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	return len(c.pending) != 0
+}
+
+// runPending runs the deferred event handlers and replies in order.
+func (c *connection) runPending() {
+	for {
+		c.pendingMu.Lock()
+		if len(c.pending) == 0 {
+			c.pendingMu.Unlock()
+			return
+		}
+		fn := c.pending[0]
+		c.pending[0] = nil
+		c.pending = c.pending[1:]
+		c.pendingMu.Unlock()
+		c.handling.Store(true)
+		fn()
+		c.handling.Store(false)
+	}
+}
+
 func (c *connection) Stop() error {
+	c.stopping.Store(true)
 	if err := c.onClose(); err != nil {
 		return err
 	}
@@ -107,6 +219,44 @@ func (c *connection) cleanup(cause ...error) {
 			close(c.abort)
 		}
 	})
+	c.notifyDisconnected()
+}
+
+// onDisconnected registers fn to be called once the connection is closed,
+// with nil after Stop and with the cause otherwise. It is called right away
+// when the connection is already closed.
+func (c *connection) onDisconnected(fn func(error)) {
+	c.disconnectedMu.Lock()
+	if !c.disconnected {
+		c.disconnectedHandlers = append(c.disconnectedHandlers, fn)
+		c.disconnectedMu.Unlock()
+		return
+	}
+	c.disconnectedMu.Unlock()
+	fn(c.disconnectedError())
+}
+
+func (c *connection) disconnectedError() error {
+	if c.stopping.Load() {
+		return nil
+	}
+	return c.closedError.Get()
+}
+
+func (c *connection) notifyDisconnected() {
+	c.disconnectedMu.Lock()
+	if c.disconnected {
+		c.disconnectedMu.Unlock()
+		return
+	}
+	c.disconnected = true
+	handlers := c.disconnectedHandlers
+	c.disconnectedHandlers = nil
+	c.disconnectedMu.Unlock()
+	err := c.disconnectedError()
+	for _, handler := range handlers {
+		handler(err)
+	}
 }
 
 func (c *connection) Dispatch(msg *message) {
@@ -126,8 +276,13 @@ func (c *connection) Dispatch(msg *message) {
 		if cb.noReply {
 			return
 		}
+		c.protocolLog.reply(cb, msg)
+		var (
+			result map[string]any
+			err    error
+		)
 		if msg.Error != nil && msg.Result == nil {
-			err := parseError(msg.Error.Error)
+			err = parseError(msg.Error.Error)
 			if log := formatCallLog(msg.Log); log != "" {
 				err = fmt.Errorf("%w%s", err, log)
 			}
@@ -141,20 +296,29 @@ func (c *connection) Dispatch(msg *message) {
 					}
 				}
 			}
-			cb.SetError(err)
 		} else {
 			// Always resolve GUIDs in responses, regardless of connection type
 			// The protocol guarantees that __create__ events arrive before responses that reference those objects
-			result, err := c.replaceGuidsWithChannels(msg.Result)
-			if err != nil {
-				cb.SetError(fmt.Errorf("failed to resolve response objects: %w", err))
+			resolved, rerr := c.replaceGuidsWithChannels(msg.Result)
+			if rerr != nil {
+				err = fmt.Errorf("failed to resolve response objects: %w", rerr)
 			} else {
-				cb.SetResult(result.(map[string]any))
+				result = resolved.(map[string]any)
 			}
 		}
+		// A call made outside of event handlers returns after the handlers of
+		// the events received before its reply, as if they had run within their
+		// dispatch. The calls of handlers cannot wait for the handlers queued
+		// behind them.
+		if cb.fromHandler || !c.deferReply(func() { cb.setResultOnce(result, err) }) {
+			cb.setResultOnce(result, err)
+		}
 		return
 	}
 	object, _ := c.objects.Load(msg.GUID)
+	if object != nil {
+		c.protocolLog.event(object.objectType, msg)
+	}
 	if method == "__create__" {
 		_, err := c.createRemoteObject(
 			object, msg.Params["type"].(string), msg.Params["guid"].(string), msg.Params["initializer"],
@@ -174,15 +338,17 @@ func (c *connection) Dispatch(msg *message) {
 		if !ok {
 			return
 		}
+		c.objectsMu.Lock()
 		object.adopt(child)
+		c.objectsMu.Unlock()
 		return
 	}
 	if method == "__dispose__" {
 		reason, ok := msg.Params["reason"]
 		if ok {
-			object.dispose(reason.(string))
+			c.dispose(object, reason.(string))
 		} else {
-			object.dispose()
+			c.dispose(object)
 		}
 		return
 	}
@@ -215,11 +381,51 @@ func (c *connection) createRemoteObject(parent *channelOwner, objectType string,
 }
 
 func (c *connection) WrapAPICall(cb func() (any, error), isInternal bool) (any, error) {
-	if _, ok := c.apiZone.Load("apiZone"); ok {
+	return c.wrapAPICall(nil, "", nil, nil, func(context.Context) (any, error) {
 		return cb()
+	}, isInternal)
+}
+
+// wrapAPICall runs cb like WrapAPICall, with object, method, params and ctx
+// describing the call for the Instrumentation.
+func (c *connection) wrapAPICall(object *channel, method string, params map[string]any, ctx context.Context, cb func(ctx context.Context) (any, error), isInternal bool) (any, error) {
+	var stack *parsedStackTrace
+	if _, ok := c.apiZone.Load("apiZone"); !ok {
+		zone := serializeCallStack(isInternal)
+		c.apiZone.Store("apiZone", zone)
+		stack = &zone
 	}
-	c.apiZone.Store("apiZone", serializeCallStack(isInternal))
-	return cb()
+	return c.instrumentAPICall(object, method, params, ctx, stack, cb, isInternal)
+}
+
+// apiCallKey is the context key of the API call that the calls made with the
+// context are part of.
+type apiCallKey struct{}
+
+// instrumentAPICall reports cb to the Instrumentation as an API call, unless
+// ctx is that of another one: the calls nested in an API call, like the
+// protocol calls it makes, are part of it. cb gets the context to make them
+// with. object, method, params and ctx describe the call, if known, and stack
+// is its call stack if it was serialized already.
+func (c *connection) instrumentAPICall(object *channel, method string, params map[string]any, ctx context.Context, stack *parsedStackTrace, cb func(ctx context.Context) (any, error), isInternal bool) (any, error) {
+	if c.instrumentation == nil || ctx != nil && ctx.Value(apiCallKey{}) != nil {
+		return cb(ctx)
+	}
+	if stack == nil {
+		zone := serializeCallStack(isInternal)
+		stack = &zone
+	}
+	call := newAPICall(stack.metadata, object, method, params, ctx)
+	parent := ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	c.instrumentation.OnCallStart(call)
+	result, err := cb(context.WithValue(parent, apiCallKey{}, call))
+	call.Duration = time.Since(call.StartTime)
+	call.Err = err
+	c.instrumentation.OnCallEnd(call)
+	return result, err
 }
 
 func (c *connection) replaceGuidsWithChannels(payload any) (any, error) {
@@ -266,19 +472,27 @@ func (c *connection) replaceGuidsWithChannels(payload any) (any, error) {
 	return payload, nil
 }
 
-func (c *connection) sendMessageToServer(object *channelOwner, method string, params any, noReply bool) (cb *protocolCallback) {
+func (c *connection) sendMessageToServer(ctx context.Context, object *channelOwner, method string, params map[string]any, noReply bool) (cb *protocolCallback) {
 	cb = newProtocolCallback(c, noReply, c.abort)
 
 	if err := c.closedError.Get(); err != nil {
 		cb.SetError(err)
 		return
 	}
+	if ctx != nil {
+		if ctx.Err() != nil {
+			cb.SetError(contextCallError(ctx))
+			return
+		}
+		cb.ctx = ctx
+	}
 	if object.wasCollected {
 		cb.SetError(errors.New("The object has been collected to prevent unbounded heap growth."))
 		return
 	}
 
 	id := c.lastID.Add(1)
+	cb.id = id
 	// The server never replies to noReply messages, so storing their callbacks
 	// would leak an entry per call for the connection's lifetime.
 	if !noReply {
@@ -304,13 +518,18 @@ func (c *connection) sendMessageToServer(object *channelOwner, method string, pa
 		"metadata": metadata,
 	}
 	if c.tracingCount.Load() > 0 && len(stack) > 0 && object.guid != "localUtils" {
-		c.LocalUtils().AddStackToTracingNoReply(id, stack)
+		c.LocalUtils().AddStackToTracingNoReply(ctx, id, stack)
 	}
 
+	cb.objectType, cb.guid, cb.method, cb.sentAt = object.objectType, object.guid, method, time.Now()
 	if err := c.transport.Send(message); err != nil {
 		cb.SetError(fmt.Errorf("could not send message: %w", err))
 		return
 	}
+	c.protocolLog.send(object.objectType, message)
+	if noReply {
+		cb.setResultOnce(nil, nil)
+	}
 
 	return
 }
@@ -336,7 +555,7 @@ func serializeCallStack(isInternal bool) parsedStackTrace {
 
 	lastInternalIndex := 0
 	for i, s := range st {
-		if pkgSourcePathPattern.MatchString(s.Frame().File) {
+		if isInternalFrame(s.Frame().File) {
 			lastInternalIndex = i
 		}
 	}
@@ -385,12 +604,14 @@ func serializeCallLocation(caller stack.Call) map[string]any {
 func newConnection(transport transport, localUtils ...*localUtilsImpl) *connection {
 	connection := &connection{
 		abort:       make(chan struct{}, 1),
+		reader:      make(chan struct{}, 1),
 		callbacks:   safe.NewSyncMap[uint32, *protocolCallback](),
 		objects:     safe.NewSyncMap[string, *channelOwner](),
 		transport:   transport,
 		isRemote:    false,
 		err:         &safeValue[error]{},
 		closedError: &safeValue[error]{},
+		protocolLog: newProtocolLogger(logger, nil),
 	}
 	if len(localUtils) > 0 {
 		connection.localUtils = localUtils[0]
@@ -416,12 +637,20 @@ func fromNullableChannel(v any) any {
 
 type protocolCallback struct {
 	connection *connection
+	id         uint32
 	done       chan struct{}
 	noReply    bool
 	abort      <-chan struct{}
-	once       sync.Once
-	value      map[string]any
-	err        error
+	// fromHandler is set for the calls made while event handlers run, which
+	// read their reply themselves.
+	fromHandler bool
+	ctx         context.Context // of the WithContext view that sent the call, if any
+	// The call, for the protocol log of its reply.
+	objectType, guid, method string
+	sentAt                   time.Time
+	once                     sync.Once
+	value                    map[string]any
+	err                      error
 }
 
 func (pc *protocolCallback) setResultOnce(result map[string]any, err error) {
@@ -438,56 +667,65 @@ func (pc *protocolCallback) waitResult() {
 	if pc.noReply {
 		return
 	}
-	// A blocking call made from within an event handler runs on the dispatch
-	// goroutine, which is the only goroutine that can deliver this reply.
-	// Blocking on pc.done would deadlock, so instead drive the receive loop
-	// re-entrantly until the reply (or connection close) arrives. If a reply
-	// dispatched here triggers another event whose handler makes a blocking
-	// call, waitResult re-enters; the recursion is bounded by the nesting depth
-	// of blocking-handler chains, which mirrors the synchronous client model.
-	//
-	// dispatchGID is 0 until the receive loop records its id; currentGoroutineID
-	// also returns 0 if the stack header can't be parsed. Guarding against 0
-	// ensures neither case is mistaken for the dispatch goroutine.
-	if gid := pc.connection.dispatchGID.Load(); gid != 0 && gid == currentGoroutineID() {
-		for {
+	var ctxDone <-chan struct{} // nil (blocks forever) without a bound context
+	if pc.ctx != nil {
+		ctxDone = pc.ctx.Done()
+	}
+	for {
+		// While the receive loop runs event handlers, it does not read
+		// messages: a call made by a handler would wait for it forever, so it
+		// takes reader and reads them itself until its reply arrives. Events
+		// read meanwhile have their handlers deferred, so handlers never run
+		// concurrently or nested. Other calls wait for the receive loop, or
+		// for a handler's call, to deliver their reply.
+		var reader chan struct{} // nil (never ready) unless reading
+		if pc.fromHandler && pc.connection.handling.Load() {
+			reader = pc.connection.reader
+		}
+		select {
+		case <-pc.done: // wait for result
+			return
+		case <-ctxDone:
+			pc.cancel()
+			return
+		case <-pc.abort:
+			// Prefer a delivered result over the close error: setResultOnce
+			// sets value/err before closing done, so a closed done means a
+			// real result is already available.
 			select {
 			case <-pc.done:
-				return
-			case <-pc.abort:
-				// Prefer a delivered result over the close error: setResultOnce
-				// sets value/err before closing done, so a closed done means a
-				// real result is already available.
-				select {
-				case <-pc.done:
-				default:
-					pc.err = errors.New("Connection closed")
-				}
+			default:
+				pc.SetError(errors.New("Connection closed"))
+			}
+			return
+		case reader <- struct{}{}:
+			// Another call may have read the reply while this one waited.
+			select {
+			case <-pc.done:
+				<-reader
 				return
 			default:
 			}
-			if !pc.connection.pollOnce() {
+			ok := pc.connection.pollOnce()
+			<-reader
+			if !ok {
 				select {
 				case <-pc.done:
 				default:
-					pc.err = errors.New("Connection closed")
+					pc.SetError(errors.New("Connection closed"))
 				}
 				return
 			}
 		}
 	}
-	select {
-	case <-pc.done: // wait for result
-		return
-	case <-pc.abort:
-		select {
-		case <-pc.done:
-			return
-		default:
-			pc.err = errors.New("Connection closed")
-			return
-		}
-	}
+}
+
+// cancel abandons the call because its bound context is done. The callback is
+// unregistered so the late reply is dropped by Dispatch, which keeps the
+// connection usable. A result that raced in first is preserved.
+func (pc *protocolCallback) cancel() {
+	pc.connection.callbacks.Delete(pc.id)
+	pc.SetError(contextCallError(pc.ctx))
 }
 
 func (pc *protocolCallback) SetError(err error) {
@@ -527,8 +765,9 @@ func newProtocolCallback(connection *connection, noReply bool, abort <-chan stru
 		}
 	}
 	return &protocolCallback{
-		connection: connection,
-		done:       make(chan struct{}, 1),
-		abort:      abort,
+		connection:  connection,
+		done:        make(chan struct{}, 1),
+		abort:       abort,
+		fromHandler: connection.handling.Load(),
 	}
 }
@@ -0,0 +1,168 @@
+package playwright
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsInternalFrame(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	require.Equal(t, path.Dir(file), pkgSourceDir)
+	require.True(t, isInternalFrame(path.Join(pkgSourceDir, "page.go")))
+	require.False(t, isInternalFrame(file))
+
+	defer func(dir string) { pkgSourceDir = dir }(pkgSourceDir)
+	for _, dir := range []string{
+		// The module cache.
+		"/home/user/go/pkg/mod/github.com/mxschmitt/playwright-go@v0.5200.0",
+		// A -trimpath build.
+		"github.com/mxschmitt/playwright-go@v0.5200.0",
+		// A checkout under another name.
+		"/src/pw",
+	} {
+		pkgSourceDir = dir
+		require.True(t, isInternalFrame(dir+"/page.go"))
+		require.False(t, isInternalFrame(dir+"/page_test.go"))
+		require.False(t, isInternalFrame(dir+"/cmd/playwright/main.go"))
+		require.False(t, isInternalFrame("/home/user/playwright-go/main.go"))
+	}
+}
+
+// newEchoConnection returns a connection whose server replies to every call
+// with {"value": <method>}, and a Page channel owner on it.
+func newEchoConnection(t testing.TB) (*connection, *channelOwner, *fakeTransport) {
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		t.deliver(&message{ID: int(msg["id"].(uint32)), Result: map[string]any{"value": msg["method"]}})
+	})
+	c := newFakeConnection(t, transport)
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+	return c, page, transport
+}
+
+func TestBlockingCallFromEventHandler(t *testing.T) {
+	c, page, transport := newEchoConnection(t)
+	page.channel.On("ping", func(ev map[string]any) { page.Emit("ping", ev["n"]) })
+	results := make(chan any, 1)
+	page.On("ping", func(any) {
+		// The receive loop runs the handler and does not read the reply.
+		require.True(t, c.handling.Load())
+		result, err := page.channel.Send("title")
+		require.NoError(t, err)
+		results <- result
+	})
+	transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": 1}})
+	require.Equal(t, "title", <-results)
+
+	result, err := page.channel.Send("url")
+	require.NoError(t, err)
+	require.Equal(t, "url", result)
+}
+
+func TestEventHandlersDoNotNest(t *testing.T) {
+	_, page, transport := newEchoConnection(t)
+	page.channel.On("ping", func(ev map[string]any) { page.Emit("ping", ev["n"]) })
+	var got []string
+	done := make(chan struct{})
+	page.On("ping", func(n int) {
+		got = append(got, fmt.Sprintf("start %d", n))
+		if n == 1 {
+			// The call reads ping 2, whose handler runs after this one.
+			_, err := page.channel.Send("title")
+			require.NoError(t, err)
+		}
+		got = append(got, fmt.Sprintf("end %d", n))
+		if n == 2 {
+			close(done)
+		}
+	})
+	transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": 1}})
+	transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": 2}})
+	<-done
+	require.Equal(t, []string{"start 1", "end 1", "start 2", "end 2"}, got)
+}
+
+func TestReplyAfterEarlierEventHandlers(t *testing.T) {
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		if msg["method"] == "goto" {
+			t.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": 1}})
+			t.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{"n": 2}})
+		}
+		t.deliver(&message{ID: int(msg["id"].(uint32)), Result: map[string]any{"value": msg["method"]}})
+	})
+	c := newFakeConnection(t, transport)
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+	page.channel.On("ping", func(ev map[string]any) { page.Emit("ping", ev["n"]) })
+	var mu sync.Mutex
+	var got []int
+	page.On("ping", func(n int) {
+		if n == 1 {
+			// Reads ping 2 and the reply of goto.
+			_, err := page.channel.Send("title")
+			require.NoError(t, err)
+		}
+		mu.Lock()
+		got = append(got, n)
+		mu.Unlock()
+	})
+	_, err := page.channel.Send("goto")
+	require.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []int{1, 2}, got)
+}
+
+func TestBlockingCallDuringEventHandler(t *testing.T) {
+	_, page, transport := newEchoConnection(t)
+	page.channel.On("ping", func(map[string]any) { page.Emit("ping") })
+	results := make(chan any, 1)
+	page.On("ping", func() {
+		called := make(chan struct{})
+		go func() {
+			// Not a handler, although the receive loop is running one.
+			result, err := page.channel.Send("title")
+			require.NoError(t, err)
+			results <- result
+			close(called)
+		}()
+		<-called
+	})
+	transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{}})
+	require.Equal(t, "title", <-results)
+}
+
+func BenchmarkBlockingCall(b *testing.B) {
+	_, page, _ := newEchoConnection(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := page.channel.Send("title"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlockingCallFromEventHandler(b *testing.B) {
+	_, page, transport := newEchoConnection(b)
+	page.channel.On("ping", func(map[string]any) { page.Emit("ping") })
+	done := make(chan struct{})
+	page.On("ping", func() {
+		defer close(done)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := page.channel.Send("title"); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.ReportAllocs()
+	transport.deliver(&message{GUID: "page@1", Method: "ping", Params: map[string]any{}})
+	<-done
+}
@@ -0,0 +1,32 @@
+package playwright
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContextBinder is implemented by [Page], [Locator], [BrowserContext] and
+// [APIRequestContext] to bind their protocol calls to a context:
+//
+//	_, err := page.WithContext(r.Context()).Goto("https://example.com")
+type ContextBinder[T any] interface {
+	// WithContext returns a view of the object whose protocol calls are bound to
+	// ctx. The view shares the state, event handlers and routes of the object.
+	//
+	// When ctx is done, the in-flight call returns immediately with an error
+	// wrapping both [ErrTimeout] and ctx.Err(), and no further calls are sent.
+	// The connection stays usable: the late reply of the abandoned call is
+	// discarded. Note that the server keeps running the abandoned operation until
+	// its own Playwright timeout elapses.
+	//
+	// The calls of a [Page] view include those of its main frame and of the
+	// locators created from the view. Other objects returned by a view, like
+	// frames, element handles or the Mouse, are not bound to ctx, and neither are
+	// calls made by event handlers. Objects passed to event handlers are the
+	// original objects, so compare them with the object, not the view.
+	WithContext(ctx context.Context) T
+}
+
+func contextCallError(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+}
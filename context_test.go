@@ -0,0 +1,69 @@
+package playwright
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContextCancelsInFlightCall(t *testing.T) {
+	pending := make(chan uint32, 1)
+	transport := newFakeTransport(func(ft *fakeTransport, msg map[string]any) {
+		if msg["method"] == "hang" {
+			pending <- msg["id"].(uint32)
+			return
+		}
+		ft.deliver(&message{ID: int(msg["id"].(uint32)), Result: map[string]any{"value": "ok"}})
+	})
+	c := newFakeConnection(t, transport)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-pending
+		cancel()
+	}()
+	_, err := c.rootObject.channel.withContext(ctx).Send("hang")
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The connection must stay usable after the abandoned call.
+	result, err := c.rootObject.channel.Send("ping")
+	require.NoError(t, err)
+	require.Equal(t, "ok", result)
+}
+
+func TestWithContextDoesNotSendWhenDone(t *testing.T) {
+	sent := 0
+	transport := newFakeTransport(func(ft *fakeTransport, msg map[string]any) {
+		sent++
+	})
+	c := newFakeConnection(t, transport)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err := c.rootObject.channel.withContext(ctx).Send("never")
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, sent)
+}
+
+func TestLocatorWithContext(t *testing.T) {
+	transport := newFakeTransport(func(ft *fakeTransport, msg map[string]any) {
+		ft.deliver(&message{ID: int(msg["id"].(uint32)), Result: map[string]any{"value": "ok"}})
+	})
+	c := newFakeConnection(t, transport)
+	frame := newFrame(&c.rootObject.channelOwner, "Frame", "frame@1", map[string]any{"name": "", "url": ""})
+	locator := newLocator(frame, "button")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bound := locator.WithContext(ctx)
+	require.ErrorIs(t, bound.Click(), context.Canceled)
+	require.ErrorIs(t, bound.Locator("span").Click(), context.Canceled)
+	// The view is the same locator, and the locator itself stays unbound.
+	require.True(t, locator.equals(bound))
+	require.NotErrorIs(t, bound.And(locator).Err(), ErrLocatorNotSameFrame)
+	require.NoError(t, locator.Click())
+}
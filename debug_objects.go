@@ -0,0 +1,146 @@
+package playwright
+
+import (
+	"sort"
+)
+
+// DebugObject is a protocol object alive in the client, see
+// [Playwright.DebugObjects].
+type DebugObject struct {
+	// Type is the protocol type, e.g. "Page", "JSHandle" or "Route".
+	Type string
+	GUID string
+	// Parent is the GUID of the object it belongs to, which disposes it along
+	// with itself. It is empty for the root object.
+	Parent string
+	// Preview is the preview of JSHandles and ElementHandles when they were
+	// created, e.g. "JSHandle@object".
+	Preview string
+}
+
+// DebugObjects is a snapshot of the protocol objects alive in the client.
+type DebugObjects struct {
+	// Objects sorted by Type, then GUID.
+	Objects []DebugObject
+}
+
+// Counts returns the number of objects by type.
+func (d DebugObjects) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, object := range d.Objects {
+		counts[object.Type]++
+	}
+	return counts
+}
+
+// ByType returns the objects grouped by type.
+func (d DebugObjects) ByType() map[string][]DebugObject {
+	byType := make(map[string][]DebugObject)
+	for _, object := range d.Objects {
+		byType[object.Type] = append(byType[object.Type], object)
+	}
+	return byType
+}
+
+// ByParent returns the objects grouped by the GUID of their parent.
+func (d DebugObjects) ByParent() map[string][]DebugObject {
+	byParent := make(map[string][]DebugObject)
+	for _, object := range d.Objects {
+		byParent[object.Parent] = append(byParent[object.Parent], object)
+	}
+	return byParent
+}
+
+// HandleLeak reports the JSHandles and ElementHandles that were never disposed
+// when the page or browser context they belong to was, see
+// [RunOptions.OnHandleLeak].
+type HandleLeak struct {
+	// Owner is the Page or BrowserContext that was disposed.
+	Owner   DebugObject
+	Handles []DebugObject
+}
+
+// DebugObjects returns the protocol objects alive in the client, e.g. to
+// count pages, handles, routes and responses while hunting a memory leak.
+// Objects of connections made with BrowserType.Connect are not included.
+func (p *Playwright) DebugObjects() DebugObjects {
+	return p.connection.debugObjects()
+}
+
+func (c *connection) debugObjects() DebugObjects {
+	c.objectsMu.RLock()
+	var objects []DebugObject
+	c.objects.Range(func(_ string, object *channelOwner) bool {
+		objects = append(objects, object.debugObject())
+		return true
+	})
+	c.objectsMu.RUnlock()
+	sortDebugObjects(objects)
+	return DebugObjects{Objects: objects}
+}
+
+// dispose disposes object and its children. Pages and browser contexts among
+// them that still hold handles are reported to onHandleLeak.
+func (c *connection) dispose(object *channelOwner, reason ...string) {
+	c.objectsMu.Lock()
+	var leaks []HandleLeak
+	if c.onHandleLeak != nil {
+		leaks = findHandleLeaks(object, nil)
+	}
+	object.dispose(reason...)
+	c.objectsMu.Unlock()
+	for _, leak := range leaks {
+		c.onHandleLeak(leak)
+	}
+}
+
+// findHandleLeaks appends the leaks of object and its children to leaks. The
+// handles of a page are reported with the page, not with its context.
+func findHandleLeaks(object *channelOwner, leaks []HandleLeak) []HandleLeak {
+	switch object.objectType {
+	case "Page", "BrowserContext":
+		var handles []DebugObject
+		var walk func(*channelOwner)
+		walk = func(o *channelOwner) {
+			for _, child := range o.objects {
+				switch child.objectType {
+				case "Page":
+					leaks = findHandleLeaks(child, leaks)
+					continue
+				case "JSHandle", "ElementHandle":
+					handles = append(handles, child.debugObject())
+				}
+				walk(child)
+			}
+		}
+		walk(object)
+		if len(handles) > 0 {
+			sortDebugObjects(handles)
+			leaks = append(leaks, HandleLeak{Owner: object.debugObject(), Handles: handles})
+		}
+	default:
+		for _, child := range object.objects {
+			leaks = findHandleLeaks(child, leaks)
+		}
+	}
+	return leaks
+}
+
+// debugObject describes c. The caller holds connection.objectsMu.
+func (c *channelOwner) debugObject() DebugObject {
+	object := DebugObject{Type: c.objectType, GUID: c.guid}
+	if c.parent != nil {
+		object.Parent = c.parent.guid
+	}
+	object.Preview, _ = c.initializer["preview"].(string)
+	return object
+}
+
+func sortDebugObjects(objects []DebugObject) {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Type != objects[j].Type {
+			return objects[i].Type < objects[j].Type
+		}
+		return objects[i].GUID < objects[j].GUID
+	})
+}
@@ -0,0 +1,53 @@
+package playwright
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugObjectsAndHandleLeaks(t *testing.T) {
+	transport := newFakeTransport(nil)
+	c := newFakeConnection(t, transport)
+	leaks := make(chan HandleLeak, 2)
+	c.onHandleLeak = func(leak HandleLeak) { leaks <- leak }
+	pw := &Playwright{}
+	pw.connection = c
+
+	create := func(parent *channelOwner, objectType, guid string, initializer map[string]any) *channelOwner {
+		object := &channelOwner{}
+		object.createChannelOwner(object, parent, objectType, guid, initializer)
+		return object
+	}
+	context := create(&c.rootObject.channelOwner, "BrowserContext", "context@1", nil)
+	page := create(context, "Page", "page@1", nil)
+	frame := create(page, "Frame", "frame@1", nil)
+	create(frame, "JSHandle", "handle@1", map[string]any{"preview": "JSHandle@object"})
+	create(frame, "ElementHandle", "handle@2", map[string]any{"preview": "JSHandle@node"})
+	create(context, "JSHandle", "handle@3", nil)
+	create(page, "Route", "route@1", nil)
+
+	objects := pw.DebugObjects()
+	counts := objects.Counts()
+	require.Equal(t, 2, counts["JSHandle"])
+	require.Equal(t, 1, counts["ElementHandle"])
+	require.Equal(t, 1, counts["Route"])
+	require.Equal(t, []DebugObject{
+		{Type: "ElementHandle", GUID: "handle@2", Parent: "frame@1", Preview: "JSHandle@node"},
+		{Type: "JSHandle", GUID: "handle@1", Parent: "frame@1", Preview: "JSHandle@object"},
+	}, objects.ByParent()["frame@1"])
+	require.Len(t, objects.ByType()["Page"], 1)
+
+	// Disposing the context reports the handles of its page separately.
+	transport.deliver(&message{GUID: "context@1", Method: "__dispose__", Params: map[string]any{}})
+	pageLeak, contextLeak := <-leaks, <-leaks
+	require.Equal(t, "page@1", pageLeak.Owner.GUID)
+	require.Len(t, pageLeak.Handles, 2)
+	require.Equal(t, "BrowserContext", contextLeak.Owner.Type)
+	require.Equal(t, []DebugObject{{Type: "JSHandle", GUID: "handle@3", Parent: "context@1"}}, contextLeak.Handles)
+
+	require.Eventually(t, func() bool {
+		return pw.DebugObjects().Counts()["JSHandle"] == 0
+	}, time.Second, time.Millisecond)
+}
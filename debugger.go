@@ -8,8 +8,8 @@ type debuggerImpl struct {
 	pausedDetails *PausedDetail
 }
 
-func (d *debuggerImpl) OnPausedStateChanged(fn func()) {
-	d.On("pausedStateChanged", fn)
+func (d *debuggerImpl) OnPausedStateChanged(fn func()) Subscription {
+	return d.On("pausedStateChanged", fn)
 }
 
 func (d *debuggerImpl) PausedDetails() (*PausedDetail, error) {
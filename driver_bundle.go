@@ -0,0 +1,275 @@
+package playwright
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// driverBundleManifestFile is the name of the manifest at the root of a driver
+// bundle.
+const driverBundleManifestFile = "manifest.json"
+
+// driverBundleManifest describes a driver bundle created by BundleDriver. The
+// bundle holds the playwright-core tarball and the Node.js archive under their
+// upstream file names, next to this manifest.
+type driverBundleManifest struct {
+	PlaywrightVersion       string `json:"playwrightVersion"`
+	NodeVersion             string `json:"nodeVersion"`
+	Platform                string `json:"platform"` // GOOS/GOARCH of the Node.js archive
+	PlaywrightCoreIntegrity string `json:"playwrightCoreIntegrity"`
+	NodeSHA256              string `json:"nodeSha256"`
+}
+
+type driverBundle struct {
+	dir      string
+	manifest driverBundleManifest
+	cleanup  func()
+}
+
+// openDriverBundle opens a driver bundle, which is either a directory or a tar
+// archive (optionally gzipped) of one. Archives are unpacked into a temporary
+// directory that close removes.
+func openDriverBundle(bundlePath string) (*driverBundle, error) {
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open driver bundle: %w", err)
+	}
+	bundle := &driverBundle{dir: bundlePath, cleanup: func() {}}
+	if !info.IsDir() {
+		dir, err := os.MkdirTemp("", "playwright-go-bundle-")
+		if err != nil {
+			return nil, fmt.Errorf("could not create temporary directory: %w", err)
+		}
+		bundle.dir = dir
+		bundle.cleanup = func() { _ = os.RemoveAll(dir) }
+		if err := extractTarFile(bundlePath, dir); err != nil {
+			bundle.close()
+			return nil, fmt.Errorf("could not extract driver bundle: %w", err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(bundle.dir, driverBundleManifestFile))
+	if err == nil {
+		err = json.Unmarshal(data, &bundle.manifest)
+	}
+	if err != nil {
+		bundle.close()
+		return nil, fmt.Errorf("could not read driver bundle manifest: %w", err)
+	}
+	return bundle, nil
+}
+
+// check reports whether the bundle can provide driver version for this host,
+// including Node.js when withNode is set.
+func (b *driverBundle) check(version string, withNode bool) error {
+	if b.manifest.PlaywrightVersion != version {
+		return fmt.Errorf("driver bundle contains playwright-core %s, expected %s", b.manifest.PlaywrightVersion, version)
+	}
+	if !withNode {
+		return nil
+	}
+	if b.manifest.NodeVersion != nodeVersion {
+		return fmt.Errorf("driver bundle contains Node.js %s, expected %s", b.manifest.NodeVersion, nodeVersion)
+	}
+	if platform := runtime.GOOS + "/" + runtime.GOARCH; b.manifest.Platform != platform {
+		return fmt.Errorf("driver bundle is for %s, not %s", b.manifest.Platform, platform)
+	}
+	return nil
+}
+
+func (b *driverBundle) filePath(name string) string {
+	return filepath.Join(b.dir, name)
+}
+
+func (b *driverBundle) close() {
+	b.cleanup()
+}
+
+// BundleDriver downloads everything DownloadDriver needs into output, so that
+// the driver can later be installed without network access by setting
+// [RunOptions.DriverBundle] (or running `playwright install --from`).
+//
+// platform selects the Node.js build as "GOOS/GOARCH"; empty means the current
+// platform. output is created as a directory, unless it ends in ".tar", in
+// which case a tar archive is written. The downloads are verified the same way
+// DownloadDriver verifies them, and the digests are recorded in the bundle.
+func (d *PlaywrightDriver) BundleDriver(output, platform string) error {
+	if platform == "" {
+		platform = runtime.GOOS + "/" + runtime.GOARCH
+	}
+	goos, goarch, ok := strings.Cut(platform, "/")
+	if !ok {
+		return fmt.Errorf("invalid platform %q, expected GOOS/GOARCH", platform)
+	}
+	suffix, err := nodePlatformSuffixFor(goos, goarch)
+	if err != nil {
+		return err
+	}
+
+	dir := output
+	asTar := strings.HasSuffix(output, ".tar")
+	if asTar {
+		if dir, err = os.MkdirTemp("", "playwright-go-bundle-"); err != nil {
+			return fmt.Errorf("could not create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir) //nolint:errcheck
+	} else if err := os.MkdirAll(dir, 0o777); err != nil {
+		return fmt.Errorf("could not create bundle directory: %w", err)
+	}
+
+	manifest := driverBundleManifest{
+		PlaywrightVersion: d.Version,
+		NodeVersion:       nodeVersion,
+		Platform:          platform,
+	}
+	d.log("Bundling driver", "platform", platform, "output", output)
+
+	if manifest.PlaywrightCoreIntegrity, err = d.playwrightPackageIntegrity(); err != nil {
+		return err
+	}
+	url := playwrightPackageURL(d.Version)
+	if err := d.downloadVerified(url, filepath.Join(dir, path.Base(url)), ProgressComponentPlaywrightCore, func(r io.Reader) error {
+		return verifyIntegrity(r, manifest.PlaywrightCoreIntegrity)
+	}); err != nil {
+		return fmt.Errorf("could not bundle playwright-core: %w", err)
+	}
+
+	archiveName, _ := nodeArchive(suffix, goos)
+	if manifest.NodeSHA256, err = d.nodeArchiveSHA256(archiveName); err != nil {
+		return err
+	}
+	if err := d.downloadVerified(nodeArchiveURL(archiveName), filepath.Join(dir, archiveName), ProgressComponentNode, func(r io.Reader) error {
+		return verifySHA256(r, manifest.NodeSHA256)
+	}); err != nil {
+		return fmt.Errorf("could not bundle Node.js: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode driver bundle manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, driverBundleManifestFile), data, 0o644); err != nil {
+		return fmt.Errorf("could not write driver bundle manifest: %w", err)
+	}
+
+	if asTar {
+		if err := writeTarFile(output, dir); err != nil {
+			return fmt.Errorf("could not write driver bundle: %w", err)
+		}
+	}
+	d.log("Bundled driver successfully", "output", output)
+	return nil
+}
+
+// downloadVerified downloads url to diskPath, removing it again unless verify
+// accepts its contents.
+func (d *PlaywrightDriver) downloadVerified(url, diskPath, component string, verify func(io.Reader) error) error {
+	err := d.downloadFile(url, diskPath, component)
+	if err == nil {
+		err = verifyFile(diskPath, verify)
+	}
+	if err != nil {
+		_ = os.Remove(diskPath)
+	}
+	return err
+}
+
+// writeTarFile writes the regular files directly inside dir to a tar archive
+// at archivePath.
+func writeTarFile(archivePath, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	tarWriter := tar.NewWriter(file)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err := addFileToTar(tarWriter, filepath.Join(dir, entry.Name())); err != nil {
+			file.Close() //nolint:errcheck
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+	return file.Close()
+}
+
+func addFileToTar(tarWriter *tar.Writer, diskPath string) error {
+	file, err := os.Open(diskPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// extractTarFile extracts the regular files of a tar archive, which may be
+// gzipped, into dir.
+func extractTarFile(archivePath, dir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	reader := bufio.NewReader(file)
+	var r io.Reader = reader
+	if magic, err := reader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gzReader.Close() //nolint:errcheck
+		r = gzReader
+	}
+
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		diskPath, err := safeJoin(dir, header.Name)
+		if err != nil {
+			return err
+		}
+		if err := writeFileFromReader(diskPath, tarReader, header.FileInfo().Mode()); err != nil {
+			return err
+		}
+	}
+}
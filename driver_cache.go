@@ -0,0 +1,305 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	driverVersionPattern   = regexp.MustCompile(`^\d+\.\d+\.\d+(-[\w.]+)?$`)
+	browserRevisionPattern = regexp.MustCompile(`^([a-z0-9_]+)-(\d+)$`)
+)
+
+// CachedDriver is a driver installation found next to the current driver
+// directory (by default in the ms-playwright-go cache directory).
+type CachedDriver struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"` // in bytes
+	// Current reports whether this is the driver version of this playwright-go
+	// release.
+	Current bool `json:"current"`
+}
+
+// InstalledBrowser is a browser revision in the shared Playwright browsers
+// directory (PLAYWRIGHT_BROWSERS_PATH, by default the ms-playwright cache
+// directory).
+type InstalledBrowser struct {
+	// Name is the browser name as used by the driver, e.g. "chromium" or
+	// "chromium-headless-shell".
+	Name     string `json:"name"`
+	Revision string `json:"revision"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"` // in bytes
+	// Referenced reports whether the current driver uses this revision.
+	Referenced bool `json:"referenced"`
+}
+
+// driverBrowsersJSON is the subset of playwright-core's browsers.json listing
+// the browser revisions a driver version downloads.
+type driverBrowsersJSON struct {
+	Browsers []struct {
+		Name              string            `json:"name"`
+		Revision          string            `json:"revision"`
+		RevisionOverrides map[string]string `json:"revisionOverrides"`
+	} `json:"browsers"`
+}
+
+// ListCachedDrivers returns the driver versions installed next to the current
+// driver directory, e.g. one per playwright-go upgrade.
+func (d *PlaywrightDriver) ListCachedDrivers() ([]CachedDriver, error) {
+	root := filepath.Dir(filepath.Clean(d.options.DriverDirectory))
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not list driver cache: %w", err)
+	}
+	var drivers []CachedDriver
+	for _, entry := range entries {
+		if !entry.IsDir() || !driverVersionPattern.MatchString(entry.Name()) {
+			continue
+		}
+		// The parent of a custom DriverDirectory may hold unrelated
+		// directories; only count those that look like a driver.
+		driverPath := filepath.Join(root, entry.Name())
+		if _, err := os.Stat(filepath.Join(driverPath, "package", "cli.js")); err != nil {
+			continue
+		}
+		size, err := directorySize(driverPath)
+		if err != nil {
+			return nil, err
+		}
+		drivers = append(drivers, CachedDriver{
+			Version: entry.Name(),
+			Path:    driverPath,
+			Size:    size,
+			Current: entry.Name() == d.Version,
+		})
+	}
+	return drivers, nil
+}
+
+// InstalledBrowsers returns the browser revisions in the Playwright browsers
+// directory, which is shared by every Playwright installation of the user.
+func (d *PlaywrightDriver) InstalledBrowsers() ([]InstalledBrowser, error) {
+	browsersPath, err := d.browsersDirectory()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(browsersPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not list browsers: %w", err)
+	}
+	referenced, _ := readReferencedBrowsers(filepath.Dir(getDriverCliJs(d.options.DriverDirectory)))
+	var browsers []InstalledBrowser
+	for _, entry := range entries {
+		match := browserRevisionPattern.FindStringSubmatch(entry.Name())
+		if !entry.IsDir() || match == nil {
+			continue
+		}
+		browserPath := filepath.Join(browsersPath, entry.Name())
+		size, err := directorySize(browserPath)
+		if err != nil {
+			return nil, err
+		}
+		browsers = append(browsers, InstalledBrowser{
+			Name:       strings.ReplaceAll(match[1], "_", "-"),
+			Revision:   match[2],
+			Path:       browserPath,
+			Size:       size,
+			Referenced: referenced[entry.Name()],
+		})
+	}
+	return browsers, nil
+}
+
+// Prune removes cached drivers other than the current version and the
+// versions in keep, then removes browser revisions that none of the remaining
+// drivers reference. Browsers registered by other Playwright installations
+// (e.g. Playwright for Node.js or Python sharing the browsers directory) are
+// kept as well. It returns the removed paths.
+//
+// Prune needs the current driver to be installed, since its browsers.json
+// defines which browser revisions are still in use.
+func (d *PlaywrightDriver) Prune(keep ...string) ([]string, error) {
+	keep = append(keep, d.Version)
+	drivers, err := d.ListCachedDrivers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the references before removing anything, so that a failure
+	// leaves the cache untouched.
+	referenced := map[string]bool{}
+	var stale []CachedDriver
+	for _, driver := range drivers {
+		if !slices.Contains(keep, driver.Version) {
+			stale = append(stale, driver)
+			continue
+		}
+		refs, err := readReferencedBrowsers(filepath.Join(driver.Path, "package"))
+		if err != nil {
+			return nil, fmt.Errorf("could not determine browsers used by driver %s: %w", driver.Version, err)
+		}
+		for name := range refs {
+			referenced[name] = true
+		}
+	}
+	if !slices.ContainsFunc(drivers, func(driver CachedDriver) bool { return driver.Current }) {
+		return nil, fmt.Errorf("driver %s is not installed, so the browsers it uses are unknown", d.Version)
+	}
+	browsersPath, err := d.browsersDirectory()
+	if err != nil {
+		return nil, err
+	}
+	for name := range readLinkedBrowsers(browsersPath, stale) {
+		referenced[name] = true
+	}
+
+	var removed []string
+	for _, driver := range stale {
+		if err := removeCachedDriver(driver.Path); err != nil {
+			return removed, err
+		}
+		d.log("Removed driver", "version", driver.Version, "path", driver.Path)
+		removed = append(removed, driver.Path)
+	}
+
+	browsers, err := d.InstalledBrowsers()
+	if err != nil {
+		return removed, err
+	}
+	for _, browser := range browsers {
+		if referenced[filepath.Base(browser.Path)] {
+			continue
+		}
+		if err := os.RemoveAll(browser.Path); err != nil {
+			return removed, fmt.Errorf("could not remove browser: %w", err)
+		}
+		d.log("Removed browser", "name", browser.Name, "revision", browser.Revision, "path", browser.Path)
+		removed = append(removed, browser.Path)
+	}
+	return removed, nil
+}
+
+// removeCachedDriver removes a driver directory while holding its install lock,
+// so that it is not pulled out from under a concurrent install.
+func removeCachedDriver(driverPath string) error {
+	driver := &PlaywrightDriver{options: &RunOptions{DriverDirectory: driverPath}}
+	unlock, err := driver.lockDriverDirectory()
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(driverPath)
+	unlock()
+	if err != nil {
+		return fmt.Errorf("could not remove driver: %w", err)
+	}
+	_ = os.Remove(driverPath + ".lock")
+	return nil
+}
+
+// browsersDirectory resolves where the driver installs browsers, mirroring
+// upstream registry: PLAYWRIGHT_BROWSERS_PATH, "0" meaning inside the package,
+// or the ms-playwright cache directory.
+func (d *PlaywrightDriver) browsersDirectory() (string, error) {
+	switch envPath := os.Getenv("PLAYWRIGHT_BROWSERS_PATH"); envPath {
+	case "":
+	case "0":
+		return filepath.Join(filepath.Dir(getDriverCliJs(d.options.DriverDirectory)), ".local-browsers"), nil
+	default:
+		return filepath.Abs(envPath)
+	}
+	cacheDirectory, err := getDefaultCacheDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDirectory, "ms-playwright"), nil
+}
+
+// readReferencedBrowsers returns the browser directory names (e.g.
+// "chromium_headless_shell-1200") referenced by the browsers.json of the
+// playwright-core package at packagePath, including per-platform overrides.
+func readReferencedBrowsers(packagePath string) (map[string]bool, error) {
+	data, err := os.ReadFile(filepath.Join(packagePath, "browsers.json"))
+	if err != nil {
+		return nil, err
+	}
+	var descriptor driverBrowsersJSON
+	if err := json.Unmarshal(data, &descriptor); err != nil {
+		return nil, fmt.Errorf("could not decode browsers.json: %w", err)
+	}
+	referenced := map[string]bool{}
+	for _, browser := range descriptor.Browsers {
+		dirName := strings.ReplaceAll(browser.Name, "-", "_")
+		referenced[dirName+"-"+browser.Revision] = true
+		for _, revision := range browser.RevisionOverrides {
+			referenced[dirName+"-"+revision] = true
+		}
+	}
+	return referenced, nil
+}
+
+// readLinkedBrowsers returns the browsers referenced by the Playwright
+// installations registered in <browsersPath>/.links, which is how upstream
+// Playwright tracks who shares the browsers directory. Links to the stale
+// drivers about to be removed are ignored.
+func readLinkedBrowsers(browsersPath string, stale []CachedDriver) map[string]bool {
+	referenced := map[string]bool{}
+	links, err := os.ReadDir(filepath.Join(browsersPath, ".links"))
+	if err != nil {
+		return referenced
+	}
+	for _, link := range links {
+		target, err := os.ReadFile(filepath.Join(browsersPath, ".links", link.Name()))
+		if err != nil {
+			continue
+		}
+		packagePath := strings.TrimSpace(string(target))
+		if slices.ContainsFunc(stale, func(driver CachedDriver) bool {
+			return strings.HasPrefix(packagePath, driver.Path+string(os.PathSeparator))
+		}) {
+			continue
+		}
+		refs, err := readReferencedBrowsers(packagePath)
+		if err != nil {
+			continue // the installation is gone
+		}
+		for name := range refs {
+			referenced[name] = true
+		}
+	}
+	return referenced
+}
+
+func directorySize(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not compute size of %s: %w", root, err)
+	}
+	return size, nil
+}
@@ -0,0 +1,255 @@
+package playwright
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DriverPatchStatus is the state of the patches playwright-go applies to the
+// driver's coreBundle.js.
+type DriverPatchStatus string
+
+const (
+	DriverPatchApplied DriverPatchStatus = "applied"
+	// DriverPatchPending means the driver is unpatched; it is patched the next
+	// time it is started.
+	DriverPatchPending DriverPatchStatus = "pending"
+	// DriverPatchUnsupported means the patched code was not found, so the
+	// driver fails to start.
+	DriverPatchUnsupported DriverPatchStatus = "unsupported"
+	// DriverPatchNotApplicable means the driver has no coreBundle.js, e.g.
+	// because it is not installed.
+	DriverPatchNotApplicable DriverPatchStatus = "not-applicable"
+)
+
+// Diagnostics is the environment report of [PlaywrightDriver.Diagnose], as
+// printed by `playwright doctor`.
+type Diagnostics struct {
+	Platform string              `json:"platform"` // GOOS/GOARCH
+	Driver   DriverDiagnostic    `json:"driver"`
+	Node     NodeDiagnostic      `json:"node"`
+	Browsers []BrowserDiagnostic `json:"browsers"`
+	// Sandbox is only reported on Linux.
+	Sandbox *SandboxDiagnostic `json:"sandbox,omitempty"`
+	Cache   []CacheDiagnostic  `json:"cache"`
+	// Problems lists what is likely to prevent Playwright from running, in
+	// plain words. It is empty when everything looks fine.
+	Problems []string `json:"problems"`
+}
+
+type DriverDiagnostic struct {
+	Directory   string            `json:"directory"`
+	Version     string            `json:"version"`
+	Installed   bool              `json:"installed"`
+	PatchStatus DriverPatchStatus `json:"patchStatus"`
+}
+
+type NodeDiagnostic struct {
+	Path string `json:"path"`
+	// Source is where the Node.js comes from: "PLAYWRIGHT_NODEJS_PATH",
+	// "system" (RunOptions.UseSystemNode) or "downloaded".
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BrowserDiagnostic struct {
+	InstalledBrowser
+	// MissingLibraries lists the shared libraries the browser needs that are
+	// not installed (Linux only). Installing them is what `playwright install
+	// --with-deps` does.
+	MissingLibraries []string `json:"missingLibraries"`
+	Error            string   `json:"error,omitempty"`
+}
+
+type SandboxDiagnostic struct {
+	Root bool `json:"root"`
+	// UserNamespaces reports whether unprivileged user namespaces, which the
+	// Chromium sandbox and Firefox rely on, are available.
+	UserNamespaces bool   `json:"userNamespaces"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+type CacheDiagnostic struct {
+	Path     string `json:"path"`
+	Exists   bool   `json:"exists"`
+	Writable bool   `json:"writable"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Diagnose inspects the driver installation and the host for the usual causes
+// of Playwright failing to install or launch browsers. Failures to inspect
+// something are recorded in the report rather than returned.
+func (d *PlaywrightDriver) Diagnose() *Diagnostics {
+	report := &Diagnostics{
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		Driver: DriverDiagnostic{
+			Directory:   d.options.DriverDirectory,
+			Version:     d.Version,
+			PatchStatus: d.driverPatchStatus(),
+		},
+		Node:     d.diagnoseNode(),
+		Browsers: []BrowserDiagnostic{},
+		Problems: []string{},
+	}
+	problem := func(format string, a ...any) {
+		report.Problems = append(report.Problems, fmt.Sprintf(format, a...))
+	}
+
+	if _, err := os.Stat(getDriverCliJs(d.options.DriverDirectory)); err == nil {
+		report.Driver.Installed = true
+	} else {
+		problem("driver %s is not installed in %s; run `playwright install`", d.Version, d.options.DriverDirectory)
+	}
+	if report.Driver.PatchStatus == DriverPatchUnsupported {
+		problem("the driver could not be patched; reinstall it")
+	}
+	// A missing downloaded Node.js is part of the driver not being installed.
+	if report.Node.Error != "" && (report.Driver.Installed || report.Node.Source != "downloaded") {
+		problem("Node.js %s does not run: %s", report.Node.Path, report.Node.Error)
+	}
+
+	browsers, err := d.InstalledBrowsers()
+	if err != nil {
+		problem("could not list browsers: %v", err)
+	}
+	var systemDirs []string
+	if runtime.GOOS == "linux" {
+		systemDirs = systemLibraryDirectories()
+	}
+	for _, browser := range browsers {
+		diagnostic := BrowserDiagnostic{InstalledBrowser: browser, MissingLibraries: []string{}}
+		if runtime.GOOS == "linux" {
+			missing, err := missingLibraries(browser.Path, systemDirs)
+			if err != nil {
+				diagnostic.Error = err.Error()
+			} else if len(missing) > 0 {
+				diagnostic.MissingLibraries = missing
+				if browser.Referenced {
+					problem("%s %s is missing libraries %s; run `playwright install-deps`", browser.Name, browser.Revision, strings.Join(missing, ", "))
+				}
+			}
+		}
+		report.Browsers = append(report.Browsers, diagnostic)
+	}
+
+	if runtime.GOOS == "linux" {
+		report.Sandbox = diagnoseSandbox()
+		if !report.Sandbox.UserNamespaces {
+			problem("unprivileged user namespaces are unavailable (%s); launch Chromium without its sandbox", report.Sandbox.Detail)
+		}
+	}
+
+	cacheDirs := []string{filepath.Dir(filepath.Clean(d.options.DriverDirectory))}
+	if browsersPath, err := d.browsersDirectory(); err == nil {
+		cacheDirs = append(cacheDirs, browsersPath)
+	}
+	for _, dir := range cacheDirs {
+		diagnostic := diagnoseCacheDirectory(dir)
+		if !diagnostic.Writable {
+			problem("cache directory %s is not writable: %s", dir, diagnostic.Error)
+		}
+		report.Cache = append(report.Cache, diagnostic)
+	}
+	return report
+}
+
+func (d *PlaywrightDriver) driverPatchStatus() DriverPatchStatus {
+	data, err := os.ReadFile(d.coreBundlePath())
+	if err != nil {
+		return DriverPatchNotApplicable
+	}
+	if isPatchedDriverBundle(data) {
+		return DriverPatchApplied
+	}
+	for original := range driverBundlePatches {
+		if strings.Contains(string(data), original) {
+			return DriverPatchPending
+		}
+	}
+	return DriverPatchUnsupported
+}
+
+func (d *PlaywrightDriver) diagnoseNode() NodeDiagnostic {
+	diagnostic := NodeDiagnostic{Path: d.nodeExecutable(), Source: "downloaded"}
+	if os.Getenv("PLAYWRIGHT_NODEJS_PATH") != "" {
+		diagnostic.Source = "PLAYWRIGHT_NODEJS_PATH"
+	} else if diagnostic.Path != getNodeExecutable(d.options.DriverDirectory) {
+		diagnostic.Source = "system"
+	}
+	output, err := exec.Command(diagnostic.Path, "--version").Output()
+	if err != nil {
+		diagnostic.Error = err.Error()
+		return diagnostic
+	}
+	diagnostic.Version = strings.TrimSpace(string(output))
+	if err := checkNodeVersion(diagnostic.Version, d.minimumNodeVersion()); err != nil {
+		diagnostic.Error = err.Error()
+	}
+	return diagnostic
+}
+
+// diagnoseSandbox checks the sysctls that disable unprivileged user namespaces
+// on common distributions.
+func diagnoseSandbox() *SandboxDiagnostic {
+	diagnostic := &SandboxDiagnostic{Root: os.Geteuid() == 0, UserNamespaces: true}
+	sysctls := []struct {
+		path, disabled, detail string
+	}{
+		{"/proc/sys/user/max_user_namespaces", "0", "user.max_user_namespaces is 0"},
+		{"/proc/sys/kernel/unprivileged_userns_clone", "0", "kernel.unprivileged_userns_clone is 0"},
+		{"/proc/sys/kernel/apparmor_restrict_unprivileged_userns", "1", "AppArmor restricts unprivileged user namespaces"},
+	}
+	for _, sysctl := range sysctls {
+		value, err := os.ReadFile(sysctl.path)
+		if err == nil && strings.TrimSpace(string(value)) == sysctl.disabled {
+			diagnostic.UserNamespaces = false
+			diagnostic.Detail = sysctl.detail
+			break
+		}
+	}
+	// Root does not need unprivileged user namespaces, but Chromium refuses to
+	// run its sandbox as root.
+	if diagnostic.Root {
+		diagnostic.UserNamespaces = true
+		diagnostic.Detail = "running as root; Chromium's sandbox is unavailable"
+	}
+	return diagnostic
+}
+
+// diagnoseCacheDirectory checks that dir, or the closest existing parent it
+// would be created in, is writable.
+func diagnoseCacheDirectory(dir string) CacheDiagnostic {
+	diagnostic := CacheDiagnostic{Path: dir}
+	existing := dir
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			diagnostic.Exists = existing == dir
+			if !info.IsDir() {
+				diagnostic.Error = existing + " is not a directory"
+				return diagnostic
+			}
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			diagnostic.Error = err.Error()
+			return diagnostic
+		}
+		existing = parent
+	}
+	file, err := os.CreateTemp(existing, ".playwright-go-doctor-*")
+	if err != nil {
+		diagnostic.Error = err.Error()
+		return diagnostic
+	}
+	file.Close()           //nolint:errcheck
+	os.Remove(file.Name()) //nolint:errcheck
+	diagnostic.Writable = true
+	return diagnostic
+}
@@ -0,0 +1,134 @@
+package playwright
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// defaultBrowserDownloadHost is where the driver downloads browsers from unless
+// PLAYWRIGHT_DOWNLOAD_HOST is set.
+const defaultBrowserDownloadHost = "https://cdn.playwright.dev"
+
+func (d *PlaywrightDriver) httpClient() *http.Client {
+	if d.options.HTTPClient != nil {
+		return d.options.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// downloadWithRetry downloads url, retrying a few times on transient failures.
+// It does not retry client errors (4xx), which are not transient. It is meant
+// for small documents; archives are streamed to disk by downloadFileWithRetry.
+func (d *PlaywrightDriver) downloadWithRetry(url string) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= 3; attempt++ {
+		var body bytes.Buffer
+		retryable, err := d.download(url, &body, nil)
+		if err == nil {
+			return body.Bytes(), nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// downloadFileWithRetry is downloadWithRetry streaming the body to diskPath,
+// reporting the bytes received to onProgress (if not nil).
+func (d *PlaywrightDriver) downloadFileWithRetry(url, diskPath string, onProgress func(done, total int64)) error {
+	var lastErr error
+	for attempt := 1; attempt <= 3; attempt++ {
+		file, err := os.Create(diskPath)
+		if err != nil {
+			return fmt.Errorf("could not create file: %w", err)
+		}
+		retryable, err := d.download(url, file, onProgress)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err, retryable = fmt.Errorf("could not write file: %w", closeErr), false
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return lastErr
+}
+
+// download fetches url into w with RunOptions.HTTPClient and the
+// downloadHeaders of url. The returned bool reports whether a failure is
+// worth retrying (network errors and 5xx are; 4xx are not).
+func (d *PlaywrightDriver) download(url string, w io.Writer, onProgress func(done, total int64)) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not create request: %w", err)
+	}
+	for name, values := range d.downloadHeaders(req.URL) {
+		req.Header[name] = values
+	}
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		return true, fmt.Errorf("could not download from %s: %w", url, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		retryable := resp.StatusCode >= 500
+		return retryable, fmt.Errorf("got non 200 status code: %d (%s) from %s", resp.StatusCode, resp.Status, url)
+	}
+	var body io.Reader = resp.Body
+	if onProgress != nil {
+		body = newProgressReader(resp.Body, resp.ContentLength, onProgress)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return true, fmt.Errorf("could not read response body: %w", err)
+	}
+	return false, nil
+}
+
+// downloadHeaders returns RunOptions.DownloadHeaders when target is on the
+// host of a configured mirror, PLAYWRIGHT_GO_NPM_REGISTRY or NODE_MIRROR, and
+// nil otherwise: the headers usually carry credentials for the mirror, which
+// must not reach the public default hosts.
+func (d *PlaywrightDriver) downloadHeaders(target *url.URL) http.Header {
+	for _, env := range []string{"PLAYWRIGHT_GO_NPM_REGISTRY", "NODE_MIRROR"} {
+		mirror, err := url.Parse(os.Getenv(env))
+		if err == nil && mirror.Host != "" && mirror.Scheme == target.Scheme && mirror.Host == target.Host {
+			return d.options.DownloadHeaders
+		}
+	}
+	return nil
+}
+
+// browserDownloadProxyEnv returns the environment that makes the driver's
+// install command use the proxy RunOptions.HTTPClient would use for the
+// browser download host, or nil to leave the environment as is.
+func (d *PlaywrightDriver) browserDownloadProxyEnv() []string {
+	if d.options.HTTPClient == nil {
+		return nil
+	}
+	transport, ok := d.options.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		return nil
+	}
+	host := os.Getenv("PLAYWRIGHT_DOWNLOAD_HOST")
+	if host == "" {
+		host = defaultBrowserDownloadHost
+	}
+	req, err := http.NewRequest(http.MethodGet, host, nil)
+	if err != nil {
+		return nil
+	}
+	proxyURL, err := transport.Proxy(req)
+	if err != nil || proxyURL == nil {
+		return nil
+	}
+	return []string{"HTTPS_PROXY=" + proxyURL.String(), "HTTP_PROXY=" + proxyURL.String()}
+}
@@ -0,0 +1,149 @@
+package playwright
+
+import (
+	"bufio"
+	"debug/elf"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// missingLibraries does what `ldd` does for upstream Playwright's dependency
+// validation: it reads the shared libraries (DT_NEEDED) of every ELF
+// executable and library under dir, and returns those that neither the
+// browser itself nor the dynamic loader's search path (systemDirs, see
+// systemLibraryDirectories) provides.
+func missingLibraries(dir string, systemDirs []string) ([]string, error) {
+	type binary struct {
+		path     string
+		needed   []string
+		runPaths []string
+	}
+	var binaries []binary
+	// Browsers ship some libraries next to their binaries and find them by
+	// rpath or LD_LIBRARY_PATH set by their launcher, so anything under dir
+	// counts as found.
+	bundled := map[string]bool{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		isLibrary := strings.Contains(entry.Name(), ".so")
+		if isLibrary {
+			bundled[entry.Name()] = true
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !isLibrary && info.Mode()&0o111 == 0 {
+			return nil
+		}
+		file, err := elf.Open(path)
+		if err != nil {
+			return nil // not an ELF file, e.g. a launcher script
+		}
+		defer file.Close() //nolint:errcheck
+		needed, err := file.ImportedLibraries()
+		if err != nil || len(needed) == 0 {
+			return nil
+		}
+		var runPaths []string
+		for _, tag := range []elf.DynTag{elf.DT_RUNPATH, elf.DT_RPATH} {
+			values, _ := file.DynString(tag)
+			for _, value := range values {
+				for _, runPath := range filepath.SplitList(value) {
+					runPaths = append(runPaths, strings.ReplaceAll(runPath, "$ORIGIN", filepath.Dir(path)))
+				}
+			}
+		}
+		binaries = append(binaries, binary{path: path, needed: needed, runPaths: runPaths})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, binary := range binaries {
+		for _, library := range binary.needed {
+			if bundled[library] || slices.Contains(missing, library) {
+				continue
+			}
+			if !libraryExists(library, binary.runPaths) && !libraryExists(library, systemDirs) {
+				missing = append(missing, library)
+			}
+		}
+	}
+	slices.Sort(missing)
+	return missing, nil
+}
+
+func libraryExists(library string, dirs []string) bool {
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, library)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// systemLibraryDirectories returns the directories the dynamic loader searches:
+// LD_LIBRARY_PATH, the directories configured in /etc/ld.so.conf and the
+// default (multiarch) library directories.
+func systemLibraryDirectories() []string {
+	dirs := filepath.SplitList(os.Getenv("LD_LIBRARY_PATH"))
+	dirs = append(dirs, readLdSoConf("/etc/ld.so.conf", 0)...)
+	for _, prefix := range []string{"/lib", "/usr/lib"} {
+		if triplet, ok := multiarchTriplets[runtime.GOARCH]; ok {
+			dirs = append(dirs, prefix+"/"+triplet)
+		}
+		dirs = append(dirs, prefix, prefix+"64")
+	}
+	return dirs
+}
+
+var multiarchTriplets = map[string]string{
+	"amd64": "x86_64-linux-gnu",
+	"arm64": "aarch64-linux-gnu",
+}
+
+// readLdSoConf returns the directories listed in an ld.so.conf file, following
+// its include directives.
+func readLdSoConf(path string, depth int) []string {
+	if depth > 8 {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close() //nolint:errcheck
+	var dirs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if pattern, ok := strings.CutPrefix(line, "include "); ok {
+			pattern = strings.TrimSpace(pattern)
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(filepath.Dir(path), pattern)
+			}
+			matches, _ := filepath.Glob(pattern)
+			for _, match := range matches {
+				dirs = append(dirs, readLdSoConf(match, depth+1)...)
+			}
+			continue
+		}
+		if line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,138 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// driverInstalledMarker is written into the driver directory as the last step
+// of an installation, holding the installed version. A driver directory with
+// cli.js but without this marker was left behind by an interrupted install.
+const driverInstalledMarker = ".installed"
+
+// driverLayout are the entries of a driver directory installed by
+// installDriver.
+var driverLayout = map[string]bool{
+	"package":             true,
+	"node":                true,
+	"node.exe":            true,
+	driverInstalledMarker: true,
+}
+
+// errLockHeld is returned by lockFile when the lock is held elsewhere and the
+// caller asked not to wait.
+var errLockHeld = errors.New("lock is held by another process")
+
+// lockDriverDirectory takes an exclusive advisory lock on
+// <DriverDirectory>.lock, waiting for other processes installing into the same
+// directory to finish. The returned func releases the lock.
+func (d *PlaywrightDriver) lockDriverDirectory() (func(), error) {
+	lockPath := filepath.Clean(d.options.DriverDirectory) + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o777); err != nil {
+		return nil, fmt.Errorf("could not create driver lock directory: %w", err)
+	}
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o666)
+	if err != nil {
+		return nil, fmt.Errorf("could not open driver lock: %w", err)
+	}
+	err = lockFile(file, false)
+	if errors.Is(err, errLockHeld) {
+		d.log("Waiting for another process to finish installing the driver", "lock", lockPath)
+		err = lockFile(file, true)
+	}
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return nil, fmt.Errorf("could not lock driver directory: %w", err)
+	}
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}, nil
+}
+
+// isPartiallyInstalledDriver reports whether the driver directory was left
+// behind by an interrupted install (no completion marker), as opposed to a
+// complete driver that fails to run. Drivers at PLAYWRIGHT_CLI_PATH are not
+// installed by us, so they are never considered partial.
+func (d *PlaywrightDriver) isPartiallyInstalledDriver() bool {
+	if os.Getenv("PLAYWRIGHT_CLI_PATH") != "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(d.options.DriverDirectory, driverInstalledMarker))
+	return os.IsNotExist(err)
+}
+
+// installDriver assembles the driver in a staging directory next to the driver
+// directory and renames it into place once complete, so that other processes
+// never observe a half-extracted driver. The caller must hold the driver lock.
+func (d *PlaywrightDriver) installDriver() error {
+	driverDirectory := filepath.Clean(d.options.DriverDirectory)
+	if err := checkReplaceableDriverDirectory(driverDirectory); err != nil {
+		return err
+	}
+	stagingDirectory := driverDirectory + ".staging"
+	// A staging directory left over from an interrupted install is garbage.
+	if err := os.RemoveAll(stagingDirectory); err != nil {
+		return fmt.Errorf("could not remove staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDirectory) //nolint:errcheck
+
+	stagingOptions := *d.options
+	stagingOptions.DriverDirectory = stagingDirectory
+	staging := &PlaywrightDriver{
+		Version:        d.Version,
+		options:        &stagingOptions,
+		bundle:         d.bundle,
+		findSystemNode: d.findSystemNode,
+	}
+	if err := staging.downloadPlaywrightPackage(); err != nil {
+		return err
+	}
+	if err := staging.downloadNode(); err != nil {
+		return err
+	}
+	if err := staging.patchDriverBundle(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(stagingDirectory, driverInstalledMarker), []byte(d.Version), 0o644); err != nil {
+		return fmt.Errorf("could not write driver marker: %w", err)
+	}
+	d.options.PlaywrightCoreIntegrity = stagingOptions.PlaywrightCoreIntegrity
+	d.options.NodeSHA256 = stagingOptions.NodeSHA256
+
+	// Only an empty, partially installed or broken driver directory can be in
+	// the way here, as checked above: a complete one is reported as up to date
+	// (or as a version mismatch) before installing.
+	if err := os.RemoveAll(driverDirectory); err != nil {
+		return fmt.Errorf("could not remove partially installed driver: %w", err)
+	}
+	if err := os.Rename(stagingDirectory, driverDirectory); err != nil {
+		return fmt.Errorf("could not move driver into place: %w", err)
+	}
+	return nil
+}
+
+// checkReplaceableDriverDirectory returns an error unless driverDirectory is
+// missing, carries the marker of a driver installed by installDriver, or holds
+// nothing but the entries of one. DriverDirectory may point to a directory
+// with unrelated files, which must not be deleted to make room for a driver.
+func checkReplaceableDriverDirectory(driverDirectory string) error {
+	entries, err := os.ReadDir(driverDirectory)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read driver directory: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(driverDirectory, driverInstalledMarker)); err == nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if !driverLayout[entry.Name()] {
+			return fmt.Errorf("driver directory %s holds %s, which is not part of a driver: remove it or choose another DriverDirectory", driverDirectory, entry.Name())
+		}
+	}
+	return nil
+}
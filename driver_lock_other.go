@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package playwright
+
+import "os"
+
+// Advisory file locks are not available here; installs are still staged and
+// renamed into place, but concurrent installers are not serialized.
+func lockFile(file *os.File, wait bool) error {
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build windows
+
+package playwright
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modkernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modkernel32.NewProc("UnlockFileEx")
+)
+
+const (
+	lockfileFailImmediately = 0x00000001
+	lockfileExclusiveLock   = 0x00000002
+	errorLockViolation      = syscall.Errno(33)
+)
+
+func lockFile(file *os.File, wait bool) error {
+	flags := uintptr(lockfileExclusiveLock)
+	if !wait {
+		flags |= lockfileFailImmediately
+	}
+	overlapped := new(syscall.Overlapped)
+	r1, _, err := procLockFileEx.Call(file.Fd(), flags, 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		if errors.Is(err, errorLockViolation) {
+			return errLockHeld
+		}
+		return err
+	}
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	overlapped := new(syscall.Overlapped)
+	r1, _, err := procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
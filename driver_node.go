@@ -0,0 +1,140 @@
+package playwright
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// minimumNodeMajor is the oldest Node.js major version assumed when the
+// installed driver's package.json does not declare engines.node, e.g. because
+// the driver is not installed yet.
+const minimumNodeMajor = 18
+
+// systemNodeInfo is the node found on PATH and its `node --version` output.
+type systemNodeInfo struct {
+	path    string
+	version string
+}
+
+// findSystemNode looks up node on PATH for [RunOptions.UseSystemNode] and
+// reads its version. The error explains why the system Node.js cannot be used.
+func findSystemNode() (systemNodeInfo, error) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		return systemNodeInfo{}, errors.New("no node found on PATH")
+	}
+	output, err := exec.Command(nodePath, "--version").Output()
+	if err != nil {
+		return systemNodeInfo{}, fmt.Errorf("could not run %s --version: %w", nodePath, err)
+	}
+	return systemNodeInfo{path: nodePath, version: strings.TrimSpace(string(output))}, nil
+}
+
+// minimumNodeVersion returns the oldest Node.js major version the installed
+// driver supports, from the engines.node range in its package.json, e.g.
+// ">=18". It falls back to minimumNodeMajor when the field is missing or not
+// of that form.
+func (d *PlaywrightDriver) minimumNodeVersion() int {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(getDriverCliJs(d.options.DriverDirectory)), "package.json"))
+	if err != nil {
+		return minimumNodeMajor
+	}
+	var packageJSON struct {
+		Engines struct {
+			Node string `json:"node"`
+		} `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &packageJSON); err != nil {
+		return minimumNodeMajor
+	}
+	return parseNodeEngines(packageJSON.Engines.Node)
+}
+
+// parseNodeEngines returns the major version of an engines.node range such as
+// ">=18" or ">= 18.19.0", or minimumNodeMajor for anything else.
+func parseNodeEngines(engines string) int {
+	majorVersion, ok := strings.CutPrefix(strings.TrimSpace(engines), ">=")
+	if !ok {
+		return minimumNodeMajor
+	}
+	majorVersion, _, _ = strings.Cut(strings.TrimPrefix(strings.TrimSpace(majorVersion), "v"), ".")
+	major, err := strconv.Atoi(majorVersion)
+	if err != nil {
+		return minimumNodeMajor
+	}
+	return major
+}
+
+// checkNodeVersion checks a `node --version` output, e.g. "v22.11.0", against
+// the minimum major version.
+func checkNodeVersion(version string, minimum int) error {
+	majorVersion, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	major, err := strconv.Atoi(majorVersion)
+	if err != nil {
+		return fmt.Errorf("could not parse Node.js version %q", version)
+	}
+	if major < minimum {
+		return fmt.Errorf("node %s is older than %d, the minimum supported by playwright-core %s", version, minimum, playwrightCliVersion)
+	}
+	return nil
+}
+
+// systemNode returns the system Node.js to run the driver with, or an error
+// stating why the downloaded one is used instead. The lookup runs once per
+// driver; its version is checked against the driver's engines.node each time,
+// since the driver may have been installed in between.
+func (d *PlaywrightDriver) systemNode() (string, error) {
+	if !d.options.UseSystemNode {
+		return "", errors.New("RunOptions.UseSystemNode is not set")
+	}
+	find := d.findSystemNode
+	if find == nil {
+		find = findSystemNode
+	}
+	node, err := find()
+	if err != nil {
+		return "", err
+	}
+	if err := checkNodeVersion(node.version, d.minimumNodeVersion()); err != nil {
+		return "", fmt.Errorf("%s: %w", node.path, err)
+	}
+	return node.path, nil
+}
+
+// nodeExecutable returns the Node.js binary the driver runs with:
+// PLAYWRIGHT_NODEJS_PATH, then a compatible system Node.js when
+// RunOptions.UseSystemNode is set, otherwise the one downloaded into the driver
+// directory.
+func (d *PlaywrightDriver) nodeExecutable() string {
+	if os.Getenv("PLAYWRIGHT_NODEJS_PATH") == "" && d.options.UseSystemNode {
+		if nodePath, err := d.systemNode(); err == nil {
+			return nodePath
+		}
+	}
+	return getNodeExecutable(d.options.DriverDirectory)
+}
+
+// usesDownloadedNode reports whether the driver needs the Node.js download,
+// and if not, logs which Node.js is used instead.
+func (d *PlaywrightDriver) usesDownloadedNode() bool {
+	if os.Getenv("PLAYWRIGHT_NODEJS_PATH") != "" {
+		d.log("Skipping Node.js download, using PLAYWRIGHT_NODEJS_PATH")
+		return false
+	}
+	if !d.options.UseSystemNode {
+		return true
+	}
+	nodePath, err := d.systemNode()
+	if err != nil {
+		d.log("Downloading Node.js, the system Node.js cannot be used", "reason", err)
+		return true
+	}
+	d.log("Skipping Node.js download, using system Node.js", "path", nodePath)
+	return false
+}
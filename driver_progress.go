@@ -0,0 +1,138 @@
+package playwright
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// ProgressPhase is the step of driver or browser installation a
+// [ProgressEvent] reports on.
+type ProgressPhase string
+
+const (
+	// ProgressPhaseDownload reports bytes downloaded of a driver archive.
+	ProgressPhaseDownload ProgressPhase = "download"
+	// ProgressPhaseExtract reports bytes read of a driver archive while it is
+	// being extracted.
+	ProgressPhaseExtract ProgressPhase = "extract"
+	// ProgressPhasePatch reports that the driver bundle is being patched.
+	ProgressPhasePatch ProgressPhase = "patch"
+	// ProgressPhaseInstall reports a browser download, parsed from the output
+	// of the driver's install command.
+	ProgressPhaseInstall ProgressPhase = "install"
+)
+
+// Components of the driver reported by [ProgressEvent]. Browser installs use
+// the name printed by the driver instead, e.g. "Chromium 140.0.7339.16
+// (playwright build v1187)".
+const (
+	ProgressComponentPlaywrightCore = "playwright-core"
+	ProgressComponentNode           = "node"
+)
+
+// ProgressEvent is passed to [RunOptions.Progress] during installation.
+type ProgressEvent struct {
+	Component string
+	Phase     ProgressPhase
+	// BytesDone and BytesTotal measure the progress of the phase. BytesTotal is
+	// -1 when unknown, and both are 0 for phases without a size (patch).
+	BytesDone  int64
+	BytesTotal int64
+	// Done is set on the last event of a phase for a component.
+	Done bool
+}
+
+func (d *PlaywrightDriver) progress(event ProgressEvent) {
+	if d.options.Progress != nil {
+		d.options.Progress(event)
+	}
+}
+
+// progressReader reports the bytes read through it. Events are throttled to
+// one per percent (or per MiB when the total is unknown), plus the final one.
+type progressReader struct {
+	r        io.Reader
+	done     int64
+	total    int64
+	reported int64
+	report   func(done, total int64)
+}
+
+func newProgressReader(r io.Reader, total int64, report func(done, total int64)) *progressReader {
+	return &progressReader{r: r, total: total, report: report}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	step := int64(1 << 20)
+	if p.total > 0 {
+		step = max(p.total/100, 1)
+	}
+	if p.done-p.reported >= step && p.done != p.total {
+		p.reported = p.done
+		p.report(p.done, p.total)
+	}
+	return n, err
+}
+
+var (
+	installDownloadingPattern = regexp.MustCompile(`^Downloading (.+) from \S+$`)
+	installProgressPattern    = regexp.MustCompile(`(\d+)% of ([\d.]+) ?(B|KiB|MiB|GiB)`)
+	installDownloadedPattern  = regexp.MustCompile(`^(.+) downloaded to \S.*$`)
+)
+
+// installProgressWriter forwards the output of the driver's install command to
+// w and turns its progress lines into ProgressEvents, e.g.:
+//
+//	Downloading Chromium 140.0.7339.16 (playwright build v1187) from https://...
+//	|■■■■■■■■                                                                        |  10% of 173.7 MiB
+//	Chromium 140.0.7339.16 (playwright build v1187) downloaded to /root/.cache/ms-playwright/chromium-1187
+type installProgressWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	report    func(ProgressEvent)
+	line      []byte
+	component string
+	total     int64
+}
+
+func (p *installProgressWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.line = append(p.line, b...)
+	for {
+		i := bytes.IndexAny(p.line, "\r\n")
+		if i < 0 {
+			break
+		}
+		p.parseLine(string(bytes.TrimSpace(p.line[:i])))
+		p.line = p.line[i+1:]
+	}
+	return p.w.Write(b)
+}
+
+func (p *installProgressWriter) parseLine(line string) {
+	if match := installDownloadingPattern.FindStringSubmatch(line); match != nil {
+		p.component, p.total = match[1], -1
+		p.report(ProgressEvent{Component: p.component, Phase: ProgressPhaseInstall, BytesTotal: p.total})
+		return
+	}
+	if match := installDownloadedPattern.FindStringSubmatch(line); match != nil {
+		total := max(p.total, 0)
+		p.report(ProgressEvent{Component: match[1], Phase: ProgressPhaseInstall, BytesDone: total, BytesTotal: p.total, Done: true})
+		p.component = ""
+		return
+	}
+	if match := installProgressPattern.FindStringSubmatch(line); match != nil && p.component != "" {
+		percent, _ := strconv.ParseInt(match[1], 10, 64)
+		size, _ := strconv.ParseFloat(match[2], 64)
+		p.total = int64(size * float64(byteUnits[match[3]]))
+		p.report(ProgressEvent{Component: p.component, Phase: ProgressPhaseInstall, BytesDone: p.total * percent / 100, BytesTotal: p.total})
+	}
+}
+
+var byteUnits = map[string]int64{"B": 1, "KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30}
@@ -0,0 +1,130 @@
+package playwright
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// npmVersionMetadata is the subset of the npm registry's per-version document
+// (GET <registry>/<name>/<version>) needed to verify a package tarball.
+type npmVersionMetadata struct {
+	Dist struct {
+		Integrity string `json:"integrity"`
+	} `json:"dist"`
+}
+
+// playwrightPackageIntegrity returns the Subresource Integrity string the
+// playwright-core tarball must match: the pinned
+// RunOptions.PlaywrightCoreIntegrity if set, then the one recorded in the
+// driver bundle, otherwise the registry's dist.integrity.
+func (d *PlaywrightDriver) playwrightPackageIntegrity() (string, error) {
+	if d.options.PlaywrightCoreIntegrity != "" {
+		return d.options.PlaywrightCoreIntegrity, nil
+	}
+	if d.bundle != nil {
+		return d.bundle.manifest.PlaywrightCoreIntegrity, nil
+	}
+	url := fmt.Sprintf("%s/playwright-core/%s", npmRegistry(), d.Version)
+	body, err := d.downloadWithRetry(url)
+	if err != nil {
+		return "", fmt.Errorf("could not download playwright-core metadata: %w", err)
+	}
+	var metadata npmVersionMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return "", fmt.Errorf("could not decode playwright-core metadata: %w", err)
+	}
+	if metadata.Dist.Integrity == "" {
+		return "", fmt.Errorf("playwright-core %s metadata from %s has no dist.integrity", d.Version, url)
+	}
+	return metadata.Dist.Integrity, nil
+}
+
+// nodeArchiveSHA256 returns the hex SHA-256 the Node.js archive must match:
+// the pinned RunOptions.NodeSHA256 if set, then the one recorded in the driver
+// bundle, otherwise the entry for archiveName in the SHASUMS256.txt published
+// alongside the release.
+func (d *PlaywrightDriver) nodeArchiveSHA256(archiveName string) (string, error) {
+	if d.options.NodeSHA256 != "" {
+		return strings.ToLower(d.options.NodeSHA256), nil
+	}
+	if d.bundle != nil {
+		return strings.ToLower(d.bundle.manifest.NodeSHA256), nil
+	}
+	url := fmt.Sprintf("%s/v%s/SHASUMS256.txt", nodejsDistHost(), nodeVersion)
+	body, err := d.downloadWithRetry(url)
+	if err != nil {
+		return "", fmt.Errorf("could not download Node.js checksums: %w", err)
+	}
+	sum, ok := findSHASUM(body, archiveName)
+	if !ok {
+		return "", fmt.Errorf("no checksum for %s in %s", archiveName, url)
+	}
+	return sum, nil
+}
+
+// findSHASUM looks up name in a SHASUMS256.txt document, whose lines have the
+// form "<hex digest>  <file name>".
+func findSHASUM(shasums []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(shasums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// verifyFile opens diskPath and passes its contents to verify.
+func verifyFile(diskPath string, verify func(io.Reader) error) error {
+	file, err := os.Open(diskPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+	return verify(file)
+}
+
+// verifyIntegrity checks r against an npm Subresource Integrity string
+// ("sha512-<base64>"). The string may list several space separated hashes;
+// only sha512 is trusted, matching what the npm registry publishes.
+func verifyIntegrity(r io.Reader, integrity string) error {
+	for _, entry := range strings.Fields(integrity) {
+		expected, ok := strings.CutPrefix(entry, "sha512-")
+		if !ok {
+			continue
+		}
+		hash := sha512.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			return err
+		}
+		actual := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+		if actual != expected {
+			return fmt.Errorf("integrity mismatch: expected sha512-%s, got sha512-%s", expected, actual)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported integrity %q: a sha512 hash is required", integrity)
+}
+
+// verifySHA256 checks r against a hex SHA-256 digest.
+func verifySHA256(r io.Reader, expected string) error {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", expected, actual)
+	}
+	return nil
+}
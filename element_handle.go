@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -17,7 +18,15 @@ func (e *elementHandleImpl) AsElement() ElementHandle {
 }
 
 func (e *elementHandleImpl) OwnerFrame() (Frame, error) {
-	channel, err := e.channel.Send("ownerFrame")
+	frame, err := e.ownerFrame(e.channel.ctx)
+	if frame == nil {
+		return nil, err
+	}
+	return frame, err
+}
+
+func (e *elementHandleImpl) ownerFrame(ctx context.Context) (*frameImpl, error) {
+	channel, err := e.channel.within(ctx).Send("ownerFrame")
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +180,13 @@ func (e *elementHandleImpl) ScrollIntoViewIfNeeded(options ...ElementHandleScrol
 }
 
 func (e *elementHandleImpl) SetInputFiles(files any, options ...ElementHandleSetInputFilesOptions) error {
-	frame, err := e.OwnerFrame()
+	return e.channel.instrumentAPICall(func(ctx context.Context) error {
+		return e.setInputFiles(ctx, files, options...)
+	})
+}
+
+func (e *elementHandleImpl) setInputFiles(ctx context.Context, files any, options ...ElementHandleSetInputFilesOptions) error {
+	frame, err := e.ownerFrame(ctx)
 	if err != nil {
 		return err
 	}
@@ -179,7 +194,7 @@ func (e *elementHandleImpl) SetInputFiles(files any, options ...ElementHandleSet
 		return errors.New("Cannot set input files to detached element")
 	}
 
-	params, err := convertInputFiles(files, frame.(*frameImpl).page.browserContext)
+	params, err := convertInputFiles(ctx, files, frame.page.browserContext)
 	if err != nil {
 		return err
 	}
@@ -191,9 +206,9 @@ func (e *elementHandleImpl) SetInputFiles(files any, options ...ElementHandleSet
 	// default (Page/BrowserContext.SetDefaultTimeout) instead of letting the
 	// serializer fall back to a hardcoded 30s, which would ignore that setting.
 	if option.Timeout == nil {
-		option.Timeout = Float(frame.(*frameImpl).page.timeoutSettings.Timeout())
+		option.Timeout = Float(frame.page.timeoutSettings.Timeout())
 	}
-	_, err = e.channel.Send("setInputFiles", params, option)
+	_, err = e.channel.within(ctx).Send("setInputFiles", params, option)
 	return err
 }
 
@@ -41,6 +41,39 @@ func (e *Error) Is(target error) bool {
 	return e.Message == err.Message
 }
 
+// DriverExitError is the cause of [ErrTargetClosed] when the driver process
+// exits while the connection is in use, e.g. because it crashed or was killed.
+// Use errors.As to get it from the error of a failed call or the one passed to
+// [Playwright.OnDisconnected].
+type DriverExitError struct {
+	// ExitCode is the exit code of the driver process, or -1 if it was killed
+	// by a signal or did not exit in time.
+	ExitCode int
+	// State describes how the process exited, e.g. "signal: killed".
+	State string
+	// Stderr holds the last lines the driver wrote to stderr.
+	Stderr string
+	// Err is the failure to read from the driver.
+	Err error
+}
+
+func (e *DriverExitError) Error() string {
+	msg := "driver exited unexpectedly"
+	if e.State != "" {
+		msg += " (" + e.State + ")"
+	} else if e.ExitCode >= 0 {
+		msg += fmt.Sprintf(" (exit status %d)", e.ExitCode)
+	}
+	if e.Stderr != "" {
+		msg += ", stderr:\n" + e.Stderr
+	}
+	return msg
+}
+
+func (e *DriverExitError) Unwrap() error {
+	return e.Err
+}
+
 func parseError(err Error) error {
 	switch err.Name {
 	case "TimeoutError":
@@ -5,14 +5,15 @@ import (
 	"reflect"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
 type EventEmitter interface {
 	Emit(name string, payload ...any) bool
 	ListenerCount(name string) int
-	On(name string, handler any)
-	Once(name string, handler any)
+	On(name string, handler any) Subscription
+	Once(name string, handler any) Subscription
 	RemoveListener(name string, handler any)
 	RemoveListeners(name string)
 }
@@ -28,34 +29,49 @@ type (
 		listeners []listener
 	}
 	listener struct {
+		id      uint64
 		handler any
-		once    bool
+		// call, if set, calls handler without reflection, see OnEvent.
+		call func(payload ...any)
+		once bool
+		// internal marks the handlers of playwright-go itself, see
+		// subscribeInternal.
+		internal bool
 	}
 )
 
+// listenerIDs numbers the listeners of all emitters.
+var listenerIDs atomic.Uint64
+
 func NewEventEmitter() EventEmitter {
 	return &eventEmitter{}
 }
 
 func (e *eventEmitter) Emit(name string, payload ...any) (hasListener bool) {
+	return e.emit(name, nil, payload...) > 0
+}
+
+// emit calls the handlers of the event that match, or all of them when match is
+// nil, and returns how many it called.
+func (e *eventEmitter) emit(name string, match func(listener) bool, payload ...any) int {
 	e.eventsMutex.Lock()
 	e.init()
 
 	evt, ok := e.events[name]
 	if !ok {
 		e.eventsMutex.Unlock()
-		return
+		return 0
 	}
 	e.eventsMutex.Unlock()
-	return evt.callHandlers(payload...) > 0
+	return evt.callHandlers(match, payload...)
 }
 
-func (e *eventEmitter) Once(name string, handler any) {
-	e.addEvent(name, handler, true)
+func (e *eventEmitter) Once(name string, handler any) Subscription {
+	return e.subscribe(name, listener{handler: handler, once: true})
 }
 
-func (e *eventEmitter) On(name string, handler any) {
-	e.addEvent(name, handler, false)
+func (e *eventEmitter) On(name string, handler any) Subscription {
+	return e.subscribe(name, listener{handler: handler})
 }
 
 func (e *eventEmitter) RemoveListener(name string, handler any) {
@@ -79,6 +95,12 @@ func (e *eventEmitter) RemoveListeners(name string) {
 
 // ListenerCount count the listeners by name, count all if name is empty
 func (e *eventEmitter) ListenerCount(name string) int {
+	return e.listenerCount(name, nil)
+}
+
+// listenerCount is ListenerCount of the listeners that match, or of all of
+// them when match is nil.
+func (e *eventEmitter) listenerCount(name string, match func(listener) bool) int {
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
 	e.init()
@@ -88,18 +110,19 @@ func (e *eventEmitter) ListenerCount(name string) int {
 		if !ok {
 			return 0
 		}
-		return evt.count()
+		return evt.count(match)
 	}
 
 	count := 0
 	for key := range e.events {
-		count += e.events[key].count()
+		count += e.events[key].count(match)
 	}
 
 	return count
 }
 
-func (e *eventEmitter) addEvent(name string, handler any, once bool) {
+// subscribe adds l, whose id it assigns, to the listeners of the event.
+func (e *eventEmitter) subscribe(name string, l listener) *subscription {
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
 	e.init()
@@ -109,7 +132,22 @@ func (e *eventEmitter) addEvent(name string, handler any, once bool) {
 			listeners: make([]listener, 0),
 		}
 	}
-	e.events[name].addHandler(handler, once)
+	id := e.events[name].addHandler(l)
+	return &subscription{remove: func() { e.removeListenerByID(name, id) }}
+}
+
+func (e *eventEmitter) removeListenerByID(name string, id uint64) {
+	e.eventsMutex.Lock()
+	defer e.eventsMutex.Unlock()
+	e.init()
+
+	if evt, ok := e.events[name]; ok {
+		evt.Lock()
+		defer evt.Unlock()
+		evt.listeners = slices.DeleteFunc(evt.listeners, func(l listener) bool {
+			return l.id == id
+		})
+	}
 }
 
 func (e *eventEmitter) init() {
@@ -119,16 +157,27 @@ func (e *eventEmitter) init() {
 	}
 }
 
-func (er *eventRegister) addHandler(handler any, once bool) {
+func (er *eventRegister) addHandler(l listener) uint64 {
 	er.Lock()
 	defer er.Unlock()
-	er.listeners = append(er.listeners, listener{handler: handler, once: once})
+	l.id = listenerIDs.Add(1)
+	er.listeners = append(er.listeners, l)
+	return l.id
 }
 
-func (er *eventRegister) count() int {
+func (er *eventRegister) count(match func(listener) bool) int {
 	er.Lock()
 	defer er.Unlock()
-	return len(er.listeners)
+	if match == nil {
+		return len(er.listeners)
+	}
+	count := 0
+	for _, l := range er.listeners {
+		if match(l) {
+			count++
+		}
+	}
+	return count
 }
 
 func (er *eventRegister) removeHandler(handler any) {
@@ -156,7 +205,7 @@ func funcIdentity(handler any) unsafe.Pointer {
 	return (*[2]unsafe.Pointer)(unsafe.Pointer(&handler))[1]
 }
 
-func (er *eventRegister) callHandlers(payloads ...any) int {
+func (er *eventRegister) callHandlers(match func(listener) bool, payloads ...any) int {
 	payloadV := make([]reflect.Value, 0)
 
 	for _, p := range payloads {
@@ -164,6 +213,10 @@ func (er *eventRegister) callHandlers(payloads ...any) int {
 	}
 
 	handle := func(l listener) {
+		if l.call != nil {
+			l.call(payloads...)
+			return
+		}
 		handlerV := reflect.ValueOf(l.handler)
 		handlerV.Call(payloadV[:int(math.Min(float64(handlerV.Type().NumIn()), float64(len(payloadV))))])
 	}
@@ -176,13 +229,18 @@ func (er *eventRegister) callHandlers(payloads ...any) int {
 	// of handlers for this dispatch: listeners added or removed by a handler
 	// take effect on the next Emit, matching Node's EventEmitter semantics.
 	er.Lock()
-	if len(er.listeners) == 0 {
+	var snapshot []listener
+	for _, l := range er.listeners {
+		if match == nil || match(l) {
+			snapshot = append(snapshot, l)
+		}
+	}
+	if len(snapshot) == 0 {
 		er.Unlock()
 		return 0
 	}
-	snapshot := slices.Clone(er.listeners)
 	er.listeners = slices.DeleteFunc(er.listeners, func(l listener) bool {
-		return l.once
+		return l.once && (match == nil || match(l))
 	})
 	er.Unlock()
 
@@ -0,0 +1,333 @@
+package playwright
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Event is an event of a page or browser context streamed by
+// [EventStreamer.Events]: a [RequestEvent], [ResponseEvent], [ConsoleEvent],
+// [DialogEvent], [DownloadEvent], [WebSocketEvent], [WorkerEvent] or
+// [PageErrorEvent].
+type Event interface {
+	// Name is the name of the event, as in [EventStreamOptions.Events].
+	Name() string
+}
+
+// EventStreamer is implemented by [Page] and [BrowserContext] to stream their
+// events to a channel.
+type EventStreamer interface {
+	// Events streams the events of the page, or of the browser context and all
+	// its pages, to the returned channel, which is closed when ctx is done or
+	// the page or browser context closes:
+	//
+	//	for event := range page.Events(ctx, playwright.EventStreamOptions{
+	//		Events: []string{"response", "pageerror"},
+	//	}) {
+	//		switch event := event.(type) {
+	//		case playwright.ResponseEvent:
+	//			fmt.Println(event.Response.Status(), event.Response.URL())
+	//		case playwright.PageErrorEvent:
+	//			fmt.Println(event.Error)
+	//		}
+	//	}
+	Events(ctx context.Context, options ...EventStreamOptions) <-chan Event
+}
+
+// RequestEvent is emitted when a page issues a request, see [Page.OnRequest].
+type RequestEvent struct{ Request Request }
+
+// ResponseEvent is emitted when the response status and headers of a request
+// are received, see [Page.OnResponse].
+type ResponseEvent struct{ Response Response }
+
+// ConsoleEvent is emitted when a page logs to the console, see
+// [Page.OnConsole].
+type ConsoleEvent struct{ Message ConsoleMessage }
+
+// DialogEvent is emitted when a dialog opens, see [Page.OnDialog]. The dialog
+// stalls the page until it is accepted or dismissed.
+type DialogEvent struct{ Dialog Dialog }
+
+// DownloadEvent is emitted when a download starts, see [Page.OnDownload].
+type DownloadEvent struct{ Download Download }
+
+// WebSocketEvent is emitted when a page opens a WebSocket, see
+// [Page.OnWebSocket].
+type WebSocketEvent struct{ WebSocket WebSocket }
+
+// WorkerEvent is emitted when a page spawns a dedicated worker, see
+// [Page.OnWorker].
+type WorkerEvent struct{ Worker Worker }
+
+// PageErrorEvent is emitted for an uncaught exception in a page, see
+// [Page.OnPageError]. Page is nil when it is not known.
+type PageErrorEvent struct {
+	Page  Page
+	Error error
+}
+
+func (RequestEvent) Name() string   { return "request" }
+func (ResponseEvent) Name() string  { return "response" }
+func (ConsoleEvent) Name() string   { return "console" }
+func (DialogEvent) Name() string    { return "dialog" }
+func (DownloadEvent) Name() string  { return "download" }
+func (WebSocketEvent) Name() string { return "websocket" }
+func (WorkerEvent) Name() string    { return "worker" }
+func (PageErrorEvent) Name() string { return "pageerror" }
+
+// EventStreamOptions configures [EventStreamer.Events].
+type EventStreamOptions struct {
+	// Events are the names of the events to stream, e.g. "request" and
+	// "console"; all of them when empty. Streaming "dialog" events means the
+	// receiver must accept or dismiss every dialog it receives, which are
+	// otherwise dismissed automatically. Dialogs that are not delivered,
+	// because the channel is full or the stream stopped, are dismissed.
+	Events []string
+	// Buffer is the capacity of the channel. Defaults to 64.
+	Buffer int
+	// Overflow applies to events that do not fit in the channel. Dropped
+	// events are logged as warnings. Defaults to OverflowDropNewest. With
+	// OverflowBlock, the events of the connection wait for the receiver, so it
+	// must not make Playwright calls while the channel is full.
+	Overflow OverflowPolicy
+}
+
+func (p *pageImpl) Events(ctx context.Context, options ...EventStreamOptions) <-chan Event {
+	s := newEventStream(options...)
+	s.add(p.OnClose(func(Page) { s.stop() }))
+	s.add(s.subscribePage(p, true)...)
+	if p.IsClosed() {
+		s.stop()
+	}
+	go s.stopWhenDone(ctx)
+	return s.events
+}
+
+func (b *browserContextImpl) Events(ctx context.Context, options ...EventStreamOptions) <-chan Event {
+	s := newEventStream(options...)
+	s.add(b.OnClose(func(BrowserContext) { s.stop() }))
+	if s.wants("request") {
+		s.add(b.OnRequest(func(r Request) { s.push(RequestEvent{r}) }))
+	}
+	if s.wants("response") {
+		s.add(b.OnResponse(func(r Response) { s.push(ResponseEvent{r}) }))
+	}
+	if s.wants("console") {
+		s.add(b.OnConsole(func(m ConsoleMessage) { s.push(ConsoleEvent{m}) }))
+	}
+	if s.wants("dialog") {
+		s.add(b.OnDialog(func(d Dialog) { s.push(DialogEvent{d}) }))
+	}
+	if s.wants("download") {
+		s.add(b.OnDownload(func(d Download) { s.push(DownloadEvent{d}) }))
+	}
+	if s.wants("pageerror") {
+		s.add(b.OnWebError(func(e WebError) { s.push(PageErrorEvent{Page: e.Page(), Error: e.Error()}) }))
+	}
+	if s.wants("websocket") || s.wants("worker") {
+		// These are only emitted by pages.
+		s.add(b.OnPage(s.addPage))
+		for _, page := range b.Pages() {
+			s.addPage(page)
+		}
+	}
+	if b.IsClosed() {
+		s.stop()
+	}
+	go s.stopWhenDone(ctx)
+	return s.events
+}
+
+type eventStream struct {
+	options EventStreamOptions
+	events  chan Event
+	done    chan struct{}
+	once    sync.Once
+	sendMu  sync.RWMutex // read-held by push, so that stop does not close events during a send
+	closed  bool         // guarded by sendMu
+	mu      sync.Mutex   // guards stopped, subs and pages
+	stopped bool
+	subs    Subscriptions
+	// pages are the subscriptions to the pages of a browser context, removed
+	// when the page closes.
+	pages map[Page]*Subscriptions
+}
+
+func newEventStream(options ...EventStreamOptions) *eventStream {
+	s := &eventStream{done: make(chan struct{}), pages: map[Page]*Subscriptions{}}
+	if len(options) == 1 {
+		s.options = options[0]
+	}
+	if s.options.Buffer <= 0 {
+		s.options.Buffer = 64
+	}
+	s.events = make(chan Event, s.options.Buffer)
+	return s
+}
+
+func (s *eventStream) wants(name string) bool {
+	return len(s.options.Events) == 0 || slices.Contains(s.options.Events, name)
+}
+
+// subscribePage subscribes to the events of page, all of them or only those
+// that its browser context does not emit itself.
+func (s *eventStream) subscribePage(page Page, all bool) []Subscription {
+	var subs []Subscription
+	if all && s.wants("request") {
+		subs = append(subs, page.OnRequest(func(r Request) { s.push(RequestEvent{r}) }))
+	}
+	if all && s.wants("response") {
+		subs = append(subs, page.OnResponse(func(r Response) { s.push(ResponseEvent{r}) }))
+	}
+	if all && s.wants("console") {
+		subs = append(subs, page.OnConsole(func(m ConsoleMessage) { s.push(ConsoleEvent{m}) }))
+	}
+	if all && s.wants("dialog") {
+		subs = append(subs, page.OnDialog(func(d Dialog) { s.push(DialogEvent{d}) }))
+	}
+	if all && s.wants("download") {
+		subs = append(subs, page.OnDownload(func(d Download) { s.push(DownloadEvent{d}) }))
+	}
+	if all && s.wants("pageerror") {
+		subs = append(subs, page.OnPageError(func(err error) { s.push(PageErrorEvent{Page: page, Error: err}) }))
+	}
+	if s.wants("websocket") {
+		subs = append(subs, page.OnWebSocket(func(ws WebSocket) { s.push(WebSocketEvent{ws}) }))
+	}
+	if s.wants("worker") {
+		subs = append(subs, page.OnWorker(func(w Worker) { s.push(WorkerEvent{w}) }))
+	}
+	return subs
+}
+
+// addPage subscribes to the events of a page of the browser context that the
+// context does not emit itself, until the page closes.
+func (s *eventStream) addPage(page Page) {
+	subs := &Subscriptions{}
+	subs.Add(s.subscribePage(page, false)...)
+	subs.Add(page.OnClose(s.removePage))
+	s.mu.Lock()
+	if _, ok := s.pages[page]; ok || s.stopped {
+		s.mu.Unlock()
+		subs.Unsubscribe()
+		return
+	}
+	s.pages[page] = subs
+	s.mu.Unlock()
+	if page.IsClosed() {
+		s.removePage(page)
+	}
+}
+
+func (s *eventStream) removePage(page Page) {
+	s.mu.Lock()
+	subs := s.pages[page]
+	delete(s.pages, page)
+	s.mu.Unlock()
+	if subs != nil {
+		subs.Unsubscribe()
+	}
+}
+
+// add keeps subs to remove them when the stream stops, or removes them right
+// away if it already has.
+func (s *eventStream) add(subs ...Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+		return
+	}
+	s.subs.Add(subs...)
+}
+
+func (s *eventStream) push(event Event) {
+	// Dialogs are dismissed once the lock is released, so that stop does not
+	// wait for the round trip.
+	for _, dropped := range s.send(event) {
+		dismissDropped(dropped)
+	}
+}
+
+// send delivers event according to the overflow policy and returns the events
+// it dropped.
+func (s *eventStream) send(event Event) (dropped []Event) {
+	s.sendMu.RLock()
+	defer s.sendMu.RUnlock()
+	if s.closed {
+		return []Event{event}
+	}
+	switch s.options.Overflow {
+	case OverflowBlock:
+		select {
+		case s.events <- event:
+		case <-s.done:
+			dropped = append(dropped, event)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.events <- event:
+				return dropped
+			default:
+			}
+			select {
+			case oldest := <-s.events:
+				logger.Warn("event stream is full, dropped an event", "event", oldest.Name())
+				dropped = append(dropped, oldest)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.events <- event:
+		default:
+			logger.Warn("event stream is full, dropped an event", "event", event.Name())
+			dropped = append(dropped, event)
+		}
+	}
+	return dropped
+}
+
+// dismissDropped dismisses the dialog of a DialogEvent that is not delivered.
+// Subscribing to dialogs disables their automatic dismissal, so the page would
+// otherwise stall on it.
+func dismissDropped(event Event) {
+	if event, ok := event.(DialogEvent); ok && event.Dialog != nil {
+		if err := event.Dialog.Dismiss(); err != nil {
+			logger.Warn("could not dismiss a dropped dialog", "error", err)
+		}
+	}
+}
+
+func (s *eventStream) stopWhenDone(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.stop()
+	case <-s.done:
+	}
+}
+
+func (s *eventStream) stop() {
+	s.once.Do(func() {
+		// Unblock a push waiting for the receiver before taking the lock.
+		close(s.done)
+		s.sendMu.Lock()
+		s.closed = true
+		close(s.events)
+		s.sendMu.Unlock()
+		s.mu.Lock()
+		s.stopped = true
+		pages := s.pages
+		s.pages = nil
+		s.mu.Unlock()
+		s.subs.Unsubscribe()
+		for _, subs := range pages {
+			subs.Unsubscribe()
+		}
+	})
+}
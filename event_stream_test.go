@@ -0,0 +1,156 @@
+package playwright
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func drainEvents(events <-chan Event) []Event {
+	var got []Event
+	for event := range events {
+		got = append(got, event)
+	}
+	return got
+}
+
+func TestEventStreamOverflow(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+	for _, tc := range []struct {
+		policy OverflowPolicy
+		want   []Event
+	}{
+		{OverflowDropNewest, []Event{PageErrorEvent{Error: errA}, PageErrorEvent{Error: errB}}},
+		{OverflowDropOldest, []Event{PageErrorEvent{Error: errB}, PageErrorEvent{Error: errC}}},
+	} {
+		s := newEventStream(EventStreamOptions{Buffer: 2, Overflow: tc.policy})
+		for _, err := range []error{errA, errB, errC} {
+			s.push(PageErrorEvent{Error: err})
+		}
+		s.stop()
+		require.Equal(t, tc.want, drainEvents(s.events))
+	}
+}
+
+// streamDialog is a Dialog that records whether it was dismissed.
+type streamDialog struct {
+	Dialog
+	dismissed bool
+}
+
+func (d *streamDialog) Dismiss() error {
+	d.dismissed = true
+	return nil
+}
+
+func TestEventStreamDismissesDroppedDialogs(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropNewest, OverflowDropOldest} {
+		s := newEventStream(EventStreamOptions{Buffer: 1, Overflow: policy})
+		first, second := &streamDialog{}, &streamDialog{}
+		s.push(DialogEvent{first})
+		s.push(DialogEvent{second})
+		s.stop()
+		delivered := drainEvents(s.events)
+		require.Len(t, delivered, 1)
+		// The delivered dialog is left to the receiver.
+		require.NotEqual(t, first.dismissed, second.dismissed)
+		require.False(t, delivered[0].(DialogEvent).Dialog.(*streamDialog).dismissed)
+	}
+
+	// A full buffer of other events.
+	s := newEventStream(EventStreamOptions{Buffer: 1})
+	s.push(RequestEvent{})
+	dialog := &streamDialog{}
+	s.push(DialogEvent{dialog})
+	require.True(t, dialog.dismissed)
+
+	// A dialog after the stream stopped.
+	s.stop()
+	dialog = &streamDialog{}
+	s.push(DialogEvent{dialog})
+	require.True(t, dialog.dismissed)
+}
+
+func TestEventStreamBlocks(t *testing.T) {
+	s := newEventStream(EventStreamOptions{Buffer: 1, Overflow: OverflowBlock})
+	s.push(RequestEvent{})
+	pushed := make(chan struct{})
+	go func() {
+		s.push(ResponseEvent{})
+		close(pushed)
+	}()
+	require.Equal(t, "request", (<-s.events).Name())
+	<-pushed
+	require.Equal(t, "response", (<-s.events).Name())
+
+	// Stopping unblocks a pending push and closes the channel.
+	s.push(RequestEvent{})
+	blocked := make(chan struct{})
+	go func() {
+		s.push(ConsoleEvent{})
+		close(blocked)
+	}()
+	s.stop()
+	<-blocked
+	require.Len(t, drainEvents(s.events), 1)
+}
+
+func TestEventStreamStop(t *testing.T) {
+	emitter := &eventEmitter{}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := newEventStream(EventStreamOptions{Events: []string{"request"}})
+	require.True(t, s.wants("request"))
+	require.False(t, s.wants("console"))
+	s.add(OnEvent(emitter, "request", func(r Request) { s.push(RequestEvent{r}) }))
+	go s.stopWhenDone(ctx)
+	emitter.Emit("request", nil)
+	cancel()
+	require.Len(t, drainEvents(s.events), 1)
+	require.Zero(t, emitter.ListenerCount("request"))
+
+	// Subscriptions made once stopped are removed right away.
+	s.add(emitter.On("request", func() {}))
+	require.Zero(t, emitter.ListenerCount("request"))
+	s.push(RequestEvent{})
+}
+
+// streamPage is a Page with only the methods used by eventStream.addPage.
+type streamPage struct {
+	Page
+	emitter eventEmitter
+	closed  bool
+}
+
+func (p *streamPage) OnClose(fn func(Page)) Subscription {
+	return OnEvent(&p.emitter, "close", fn)
+}
+
+func (p *streamPage) OnWorker(fn func(Worker)) Subscription {
+	return OnEvent(&p.emitter, "worker", fn)
+}
+
+func (p *streamPage) IsClosed() bool { return p.closed }
+
+func TestEventStreamRemovesClosedPages(t *testing.T) {
+	s := newEventStream(EventStreamOptions{Events: []string{"worker"}})
+	page, other := &streamPage{}, &streamPage{}
+	s.addPage(page)
+	s.addPage(page)
+	s.addPage(other)
+	require.Equal(t, 1, page.emitter.ListenerCount("worker"))
+
+	page.closed = true
+	page.emitter.Emit("close", Page(page))
+	require.Zero(t, page.emitter.ListenerCount("worker"))
+	require.Zero(t, page.emitter.ListenerCount("close"))
+	require.Equal(t, 1, other.emitter.ListenerCount("worker"))
+
+	// A page that closed before it was added is not kept.
+	s.addPage(page)
+	require.Zero(t, page.emitter.ListenerCount("worker"))
+
+	s.stop()
+	require.Zero(t, other.emitter.ListenerCount("worker"))
+}
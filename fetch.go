@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -63,6 +64,13 @@ func newApiRequestImpl(pw *Playwright) *apiRequestImpl {
 }
 
 type apiRequestContextImpl struct {
+	*apiRequestContextState
+	// channel is bound to a context in the views returned by WithContext,
+	// which share the apiRequestContextState of the request context.
+	channel *channel
+}
+
+type apiRequestContextState struct {
 	channelOwner
 	tracing         *tracingImpl
 	closeReason     *string
@@ -71,17 +79,23 @@ type apiRequestContextImpl struct {
 }
 
 func (r *apiRequestContextImpl) Dispose(options ...APIRequestContextDisposeOptions) error {
+	return r.channel.instrumentAPICall(func(ctx context.Context) error {
+		return r.dispose(ctx, options...)
+	})
+}
+
+func (r *apiRequestContextImpl) dispose(ctx context.Context, options ...APIRequestContextDisposeOptions) error {
 	if len(options) == 1 {
 		r.closeReason = options[0].Reason
 	}
 	// Flush any HARs recorded via this request context before disposing,
 	// matching upstream dispose() which calls _exportAllHars().
 	if r.tracing != nil && len(r.tracing.harRecorders) > 0 {
-		if err := r.tracing.exportAllHars(); err != nil {
+		if err := r.tracing.exportAllHars(ctx); err != nil {
 			return err
 		}
 	}
-	_, err := r.channel.Send("dispose", map[string]any{
+	_, err := r.channel.within(ctx).Send("dispose", map[string]any{
 		"reason": r.closeReason,
 	})
 	if errors.Is(err, ErrTargetClosed) {
@@ -365,13 +379,18 @@ func (r *apiRequestContextImpl) StorageState(options ...APIRequestContextStorage
 	return &storageState, nil
 }
 
+func (r *apiRequestContextImpl) WithContext(ctx context.Context) APIRequestContext {
+	return &apiRequestContextImpl{apiRequestContextState: r.apiRequestContextState, channel: r.channelOwner.channel.withContext(ctx)}
+}
+
 func (r *apiRequestContextImpl) Tracing() Tracing {
 	return r.tracing
 }
 
 func newAPIRequestContext(parent *channelOwner, objectType string, guid string, initializer map[string]any) *apiRequestContextImpl {
-	rc := &apiRequestContextImpl{}
+	rc := &apiRequestContextImpl{apiRequestContextState: &apiRequestContextState{}}
 	rc.createChannelOwner(rc, parent, objectType, guid, initializer)
+	rc.channel = rc.channelOwner.channel
 	if tracingValue := initializer["tracing"]; tracingValue != nil {
 		rc.tracing = fromNullableChannel(tracingValue).(*tracingImpl)
 	}
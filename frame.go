@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -11,6 +12,13 @@ import (
 )
 
 type frameImpl struct {
+	*frameState
+	// channel is bound to a context in the views returned by withContext,
+	// which share the frameState of the frame.
+	channel *channel
+}
+
+type frameState struct {
 	channelOwner
 	detached    bool
 	page        *pageImpl
@@ -31,13 +39,14 @@ func newFrame(parent *channelOwner, objectType string, guid string, initializer
 			}
 		}
 	}
-	f := &frameImpl{
+	f := &frameImpl{frameState: &frameState{
 		name:        initializer["name"].(string),
 		url:         initializer["url"].(string),
 		loadStates:  loadStates,
 		childFrames: make([]Frame, 0),
-	}
+	}}
 	f.createChannelOwner(f, parent, objectType, guid, initializer)
+	f.channel = f.channelOwner.channel
 
 	channelOwner := fromNullableChannel(initializer["parentFrame"])
 	if channelOwner != nil {
@@ -50,6 +59,10 @@ func newFrame(parent *channelOwner, objectType string, guid string, initializer
 	return f
 }
 
+func (f *frameImpl) withContext(ctx context.Context) *frameImpl {
+	return &frameImpl{frameState: f.frameState, channel: f.channelOwner.channel.withContext(ctx)}
+}
+
 func (f *frameImpl) URL() string {
 	f.RLock()
 	defer f.RUnlock()
@@ -265,7 +278,7 @@ func (f *frameImpl) setNavigationWaiter(timeout *float64) (*waiter, error) {
 	if f.page == nil {
 		return nil, errors.New("page does not exist")
 	}
-	waiter := newWaiter()
+	waiter := newWaiter().WithChannel(f.channel)
 	if timeout != nil {
 		waiter.WithTimeout(*timeout)
 	} else {
@@ -281,7 +294,7 @@ func (f *frameImpl) setNavigationWaiter(timeout *float64) (*waiter, error) {
 	waiter.RejectOnEvent(f.page, "crash", fmt.Errorf("Navigation failed because page crashed!"))
 	waiter.RejectOnEvent(f.page, "framedetached", fmt.Errorf("Navigating frame was detached!"), func(payload any) bool {
 		frame, ok := payload.(*frameImpl)
-		if ok && frame == f {
+		if ok && frame.frameState == f.frameState {
 			return true
 		}
 		return false
@@ -490,7 +503,13 @@ func (f *frameImpl) Hover(selector string, options ...FrameHoverOptions) error {
 }
 
 func (f *frameImpl) SetInputFiles(selector string, files any, options ...FrameSetInputFilesOptions) error {
-	params, err := convertInputFiles(files, f.page.browserContext)
+	return f.channel.instrumentAPICall(func(ctx context.Context) error {
+		return f.setInputFiles(ctx, selector, files, options...)
+	})
+}
+
+func (f *frameImpl) setInputFiles(ctx context.Context, selector string, files any, options ...FrameSetInputFilesOptions) error {
+	params, err := convertInputFiles(ctx, files, f.page.browserContext)
 	if err != nil {
 		return err
 	}
@@ -505,7 +524,7 @@ func (f *frameImpl) SetInputFiles(selector string, files any, options ...FrameSe
 	if option.Timeout == nil {
 		option.Timeout = Float(f.page.timeoutSettings.Timeout())
 	}
-	_, err = f.channel.Send("setInputFiles", params, option)
+	_, err = f.channel.within(ctx).Send("setInputFiles", params, option)
 	return err
 }
 
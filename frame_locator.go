@@ -104,7 +104,7 @@ func (fl *frameLocatorImpl) Locator(selectorOrLocator any, options ...FrameLocat
 	}
 	locator, ok := selectorOrLocator.(*locatorImpl)
 	if ok {
-		if fl.frame != locator.frame {
+		if fl.frame.frameState != locator.frame.frameState {
 			return locator.withError(ErrLocatorNotSameFrame)
 		}
 		return newLocator(
@@ -20,6 +20,7 @@ type APIRequest interface {
 // If you want API requests that do **not** share cookies with the browser, create an isolated context via
 // [APIRequest.NewContext]. Such `APIRequestContext` object will have its own isolated cookie storage.
 type APIRequestContext interface {
+	ContextBinder[APIRequestContext]
 	// Sends HTTP(S) [DELETE] request and returns its
 	// response. The method will populate request cookies from the context and update context cookies from the response.
 	// The method will automatically follow redirects.
@@ -149,13 +150,13 @@ type APIResponseAssertions interface {
 type Browser interface {
 	EventEmitter
 	// Emitted when a new browser context is created.
-	OnContext(fn func(BrowserContext))
+	OnContext(fn func(BrowserContext)) Subscription
 
 	// Emitted when Browser gets disconnected from the browser application. This might happen because of one of the
 	// following:
 	//  - Browser application is closed or crashed.
 	//  - The [Browser.Close] method was called.
-	OnDisconnected(fn func(Browser))
+	OnDisconnected(fn func(Browser)) Subscription
 
 	// Get the browser type (chromium, firefox or webkit) that the browser belongs to.
 	BrowserType() BrowserType
@@ -235,10 +236,12 @@ type Browser interface {
 // Non-persistent browser contexts don't write any browsing data to disk.
 type BrowserContext interface {
 	EventEmitter
+	ContextBinder[BrowserContext]
+	EventStreamer
 	// This event is not emitted.
 	//
 	// Deprecated: Background pages have been removed from Chromium together with Manifest V2 extensions.
-	OnBackgroundPage(fn func(Page))
+	OnBackgroundPage(fn func(Page)) Subscription
 
 	// Playwright has ability to mock clock and passage of time.
 	Clock() Clock
@@ -254,11 +257,11 @@ type BrowserContext interface {
 	//  - Browser context is closed.
 	//  - Browser application is closed or crashed.
 	//  - The [Browser.Close] method was called.
-	OnClose(fn func(BrowserContext))
+	OnClose(fn func(BrowserContext)) Subscription
 
 	// Emitted when JavaScript within the page calls one of console API methods, e.g. `console.log` or `console.dir`.
 	// The arguments passed into `console.log` and the page are available on the [ConsoleMessage] event handler argument.
-	OnConsole(fn func(ConsoleMessage))
+	OnConsole(fn func(ConsoleMessage)) Subscription
 
 	// Emitted when a JavaScript dialog appears, such as `alert`, `prompt`, `confirm` or `beforeunload`. Listener **must**
 	// either [Dialog.Accept] or [Dialog.Dismiss] the dialog - otherwise the page will
@@ -266,24 +269,24 @@ type BrowserContext interface {
 	// and actions like click will never finish.
 	//
 	// [freeze]: https://developer.mozilla.org/en-US/docs/Web/JavaScript/EventLoop#never_blocking
-	OnDialog(fn func(Dialog))
+	OnDialog(fn func(Dialog)) Subscription
 
 	// Emitted when attachment download started in any page belonging to this context. User can access basic file
 	// operations on downloaded content via the passed [Download] instance. See also [Page.OnDownload] to receive events
 	// about a specific page.
-	OnDownload(fn func(Download))
+	OnDownload(fn func(Download)) Subscription
 
 	// Emitted when a frame is attached in any page belonging to this context. See also [Page.OnFrameAttached] to receive
 	// events about a specific page.
-	OnFrameAttached(fn func(Frame))
+	OnFrameAttached(fn func(Frame)) Subscription
 
 	// Emitted when a frame is detached in any page belonging to this context. See also [Page.OnFrameDetached] to receive
 	// events about a specific page.
-	OnFrameDetached(fn func(Frame))
+	OnFrameDetached(fn func(Frame)) Subscription
 
 	// Emitted when a frame is navigated to a new url in any page belonging to this context. See also
 	// [Page.OnFrameNavigated] to receive events about navigations in a specific page.
-	OnFrameNavigated(fn func(Frame))
+	OnFrameNavigated(fn func(Frame)) Subscription
 
 	// The event is emitted when a new Page is created in the BrowserContext. The page may still be loading. The event
 	// will also fire for popup pages. See also [Page.OnPopup] to receive events about popups relevant to a specific page.
@@ -294,41 +297,41 @@ type BrowserContext interface {
 	// methods on the [Page].
 	// **NOTE** Use [Page.WaitForLoadState] to wait until the page gets to a particular state (you should not need it in
 	// most cases).
-	OnPage(fn func(Page))
+	OnPage(fn func(Page)) Subscription
 
 	// Emitted when a page in this context is closed. See also [Page.OnClose] to receive events about a specific page.
-	OnPageClose(fn func(Page))
+	OnPageClose(fn func(Page)) Subscription
 
 	// Emitted when the JavaScript [`load`] event is dispatched
 	// in any page belonging to this context. See also [Page.OnLoad] to receive events about a specific page.
 	//
 	// [`load`]: https://developer.mozilla.org/en-US/docs/Web/Events/load
-	OnPageLoad(fn func(Page))
+	OnPageLoad(fn func(Page)) Subscription
 
 	// Emitted when exception is unhandled in any of the pages in this context. To listen for errors from a particular
 	// page, use [Page.OnPageError] instead.
-	OnWebError(fn func(WebError))
+	OnWebError(fn func(WebError)) Subscription
 
 	// Emitted when a request is issued from any pages created through this context. The [request] object is read-only. To
 	// only listen for requests from a particular page, use [Page.OnRequest].
 	// In order to intercept and mutate requests, see [BrowserContext.Route] or [Page.Route].
-	OnRequest(fn func(Request))
+	OnRequest(fn func(Request)) Subscription
 
 	// Emitted when a request fails, for example by timing out. To only listen for failed requests from a particular page,
 	// use [Page.OnRequestFailed].
 	// **NOTE** HTTP Error responses, such as 404 or 503, are still successful responses from HTTP standpoint, so request
 	// will complete with [BrowserContext.OnRequestFinished] event and not with [BrowserContext.OnRequestFailed].
-	OnRequestFailed(fn func(Request))
+	OnRequestFailed(fn func(Request)) Subscription
 
 	// Emitted when a request finishes successfully after downloading the response body. For a successful response, the
 	// sequence of events is `request`, `response` and `requestfinished`. To listen for successful requests from a
 	// particular page, use [Page.OnRequestFinished].
-	OnRequestFinished(fn func(Request))
+	OnRequestFinished(fn func(Request)) Subscription
 
 	// Emitted when [response] status and headers are received for a request. For a successful response, the sequence of
 	// events is `request`, `response` and `requestfinished`. To listen for response events from a particular page, use
 	// [Page.OnResponse].
-	OnResponse(fn func(Response))
+	OnResponse(fn func(Response)) Subscription
 
 	// Adds cookies into this browser context. All pages within this context will have these cookies installed. Cookies
 	// can be obtained via [BrowserContext.Cookies].
@@ -562,6 +565,7 @@ type BrowserContext interface {
 // BrowserType provides methods to launch a specific browser instance or connect to an existing one. The following is
 // a typical example of using Playwright to drive automation:
 type BrowserType interface {
+	BrowserServerLauncher
 	// This method attaches Playwright to an existing browser instance created via `BrowserType.launchServer` in Node.js.
 	// **NOTE** The major and minor version of the Playwright instance that connects needs to match the version of
 	// Playwright that launches the browser (1.2.3 → is compatible with 1.2.x).
@@ -630,7 +634,7 @@ type BrowserType interface {
 type CDPSession interface {
 	EventEmitter
 	// Emitted when the session is closed, either because the target was closed or `session.detach()` was called.
-	OnClose(fn func(CDPSession))
+	OnClose(fn func(CDPSession)) Subscription
 
 	// Detaches the CDPSession from the target. Once detached, the CDPSession object won't emit any events and can't be
 	// used to send messages.
@@ -781,7 +785,7 @@ type Credentials interface {
 // Obtain the debugger instance via [BrowserContext.Debugger].
 type Debugger interface {
 	// Emitted when the debugger pauses or resumes.
-	OnPausedStateChanged(fn func())
+	OnPausedStateChanged(fn func()) Subscription
 
 	// Returns details about the currently paused call. Returns `null` if the debugger is not paused.
 	PausedDetails() (*PausedDetail, error)
@@ -2322,6 +2326,7 @@ type Keyboard interface {
 //
 // [Learn more about locators]: https://playwright.dev/docs/locators
 type Locator interface {
+	ContextBinder[Locator]
 	// When the locator points to a list of elements, this returns an array of locators, pointing to their respective
 	// elements.
 	// **NOTE** [Locator.All] does not wait for elements to match the locator, and instead immediately returns whatever is
@@ -3046,6 +3051,7 @@ type Locator interface {
 // The [LocatorAssertions] class provides assertion methods that can be used to make assertions about the [Locator]
 // state in the tests.
 type LocatorAssertions interface {
+	LocatorScreenshotAssertions
 	// Makes the assertion check for the opposite condition.
 	Not() LocatorAssertions
 
@@ -3274,20 +3280,22 @@ type Mouse interface {
 // [`EventEmitter`]: https://nodejs.org/api/events.html#events_class_eventemitter
 type Page interface {
 	EventEmitter
+	ContextBinder[Page]
+	EventStreamer
 	// Playwright has ability to mock clock and passage of time.
 	Clock() Clock
 
 	// Emitted when the page closes.
-	OnClose(fn func(Page))
+	OnClose(fn func(Page)) Subscription
 
 	// Emitted when JavaScript within the page calls one of console API methods, e.g. `console.log` or `console.dir`.
 	// The arguments passed into `console.log` are available on the [ConsoleMessage] event handler argument.
-	OnConsole(fn func(ConsoleMessage))
+	OnConsole(fn func(ConsoleMessage)) Subscription
 
 	// Emitted when the page crashes. Browser pages might crash if they try to allocate too much memory. When the page
 	// crashes, ongoing and subsequent operations will throw.
 	// The most common way to deal with crashes is to catch an exception:
-	OnCrash(fn func(Page))
+	OnCrash(fn func(Page)) Subscription
 
 	// Emitted when a JavaScript dialog appears, such as `alert`, `prompt`, `confirm` or `beforeunload`. Listener **must**
 	// either [Dialog.Accept] or [Dialog.Dismiss] the dialog - otherwise the page will
@@ -3295,38 +3303,38 @@ type Page interface {
 	// and actions like click will never finish.
 	//
 	// [freeze]: https://developer.mozilla.org/en-US/docs/Web/JavaScript/EventLoop#never_blocking
-	OnDialog(fn func(Dialog))
+	OnDialog(fn func(Dialog)) Subscription
 
 	// Emitted when the JavaScript
 	// [`DOMContentLoaded`] event is dispatched.
 	//
 	// [`DOMContentLoaded`]: https://developer.mozilla.org/en-US/docs/Web/Events/DOMContentLoaded
-	OnDOMContentLoaded(fn func(Page))
+	OnDOMContentLoaded(fn func(Page)) Subscription
 
 	// Emitted when attachment download started. User can access basic file operations on downloaded content via the
 	// passed [Download] instance.
-	OnDownload(fn func(Download))
+	OnDownload(fn func(Download)) Subscription
 
 	// Emitted when a file chooser is supposed to appear, such as after clicking the  `<input type=file>`. Playwright can
 	// respond to it via setting the input files using [FileChooser.SetFiles] that can be uploaded after that.
-	OnFileChooser(fn func(FileChooser))
+	OnFileChooser(fn func(FileChooser)) Subscription
 
 	// Emitted when a frame is attached.
-	OnFrameAttached(fn func(Frame))
+	OnFrameAttached(fn func(Frame)) Subscription
 
 	// Emitted when a frame is detached.
-	OnFrameDetached(fn func(Frame))
+	OnFrameDetached(fn func(Frame)) Subscription
 
 	// Emitted when a frame is navigated to a new url.
-	OnFrameNavigated(fn func(Frame))
+	OnFrameNavigated(fn func(Frame)) Subscription
 
 	// Emitted when the JavaScript [`load`] event is dispatched.
 	//
 	// [`load`]: https://developer.mozilla.org/en-US/docs/Web/Events/load
-	OnLoad(fn func(Page))
+	OnLoad(fn func(Page)) Subscription
 
 	// Emitted when an uncaught exception happens within the page.
-	OnPageError(fn func(error))
+	OnPageError(fn func(error)) Subscription
 
 	// Emitted when the page opens a new tab or window. This event is emitted in addition to the [BrowserContext.OnPage],
 	// but only for popups relevant to this page.
@@ -3337,35 +3345,35 @@ type Page interface {
 	// methods on the [Page].
 	// **NOTE** Use [Page.WaitForLoadState] to wait until the page gets to a particular state (you should not need it in
 	// most cases).
-	OnPopup(fn func(Page))
+	OnPopup(fn func(Page)) Subscription
 
 	// Emitted when a page issues a request. The [request] object is read-only. In order to intercept and mutate requests,
 	// see [Page.Route] or [BrowserContext.Route].
-	OnRequest(fn func(Request))
+	OnRequest(fn func(Request)) Subscription
 
 	// Emitted when a request fails, for example by timing out.
 	// **NOTE** HTTP Error responses, such as 404 or 503, are still successful responses from HTTP standpoint, so request
 	// will complete with [Page.OnRequestFinished] event and not with [Page.OnRequestFailed]. A request will only be
 	// considered failed when the client cannot get an HTTP response from the server, e.g. due to network error
 	// net::ERR_FAILED.
-	OnRequestFailed(fn func(Request))
+	OnRequestFailed(fn func(Request)) Subscription
 
 	// Emitted when a request finishes successfully after downloading the response body. For a successful response, the
 	// sequence of events is `request`, `response` and `requestfinished`.
-	OnRequestFinished(fn func(Request))
+	OnRequestFinished(fn func(Request)) Subscription
 
 	// Emitted when [response] status and headers are received for a request. For a successful response, the sequence of
 	// events is `request`, `response` and `requestfinished`.
-	OnResponse(fn func(Response))
+	OnResponse(fn func(Response)) Subscription
 
 	// Emitted when [WebSocket] request is sent.
-	OnWebSocket(fn func(WebSocket))
+	OnWebSocket(fn func(WebSocket)) Subscription
 
 	// Emitted when a dedicated [WebWorker] is spawned
 	// by the page.
 	//
 	// [WebWorker]: https://developer.mozilla.org/en-US/docs/Web/API/Web_Workers_API
-	OnWorker(fn func(Worker))
+	OnWorker(fn func(Worker)) Subscription
 
 	// Adds a script which would be evaluated in one of the following scenarios:
 	//  - Whenever the page is navigated.
@@ -4403,6 +4411,7 @@ type Page interface {
 // The [PageAssertions] class provides assertion methods that can be used to make assertions about the [Page] state in
 // the tests.
 type PageAssertions interface {
+	PageScreenshotAssertions
 	// Makes the assertion check for the opposite condition.
 	Not() PageAssertions
 
@@ -4430,6 +4439,8 @@ type PageAssertions interface {
 // reached. You can pass this timeout as an option.
 // By default, the timeout for assertions is set to 5 seconds.
 type PlaywrightAssertions interface {
+	PlaywrightPollAssertions
+	PlaywrightSoftAssertions
 	// Creates a [APIResponseAssertions] object for the given [APIResponse].
 	//
 	//  response: [APIResponse] object to use for assertions.
@@ -4828,16 +4839,16 @@ type WebError interface {
 // If you want to intercept or modify WebSocket frames, consider using [WebSocketRoute].
 type WebSocket interface {
 	// Fired when the websocket closes.
-	OnClose(fn func(WebSocket))
+	OnClose(fn func(WebSocket)) Subscription
 
 	// Fired when the websocket receives a frame.
-	OnFrameReceived(fn func([]byte))
+	OnFrameReceived(fn func([]byte)) Subscription
 
 	// Fired when the websocket sends a frame.
-	OnFrameSent(fn func([]byte))
+	OnFrameSent(fn func([]byte)) Subscription
 
 	// Fired when the websocket has an error.
-	OnSocketError(fn func(string))
+	OnSocketError(fn func(string)) Subscription
 
 	// Indicates that the web socket has been closed.
 	IsClosed() bool
@@ -4983,10 +4994,10 @@ type Worker interface {
 	// terminated.
 	//
 	// [WebWorker]: https://developer.mozilla.org/en-US/docs/Web/API/Web_Workers_API
-	OnClose(fn func(Worker))
+	OnClose(fn func(Worker)) Subscription
 
 	// Emitted when JavaScript within the worker calls one of console API methods, e.g. `console.log` or `console.dir`.
-	OnConsole(fn func(ConsoleMessage))
+	OnConsole(fn func(ConsoleMessage)) Subscription
 
 	// Returns the return value of “[object Object]”.
 	// If the function passed to the [Worker.Evaluate] returns a [Promise], then [Worker.Evaluate] would wait for the
@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -30,7 +31,9 @@ type fileItem struct {
 //
 //   - files should be one of: string, []string, InputFile, []InputFile,
 //     string: local file path
-func convertInputFiles(files any, context *browserContextImpl) (*inputFiles, error) {
+//
+// Its calls are part of the API call of ctx.
+func convertInputFiles(ctx context.Context, files any, browserContext *browserContextImpl) (*inputFiles, error) {
 	var (
 		converted = &inputFiles{}
 		paths     []string
@@ -63,7 +66,7 @@ func convertInputFiles(files any, context *browserContextImpl) (*inputFiles, err
 		return nil, err
 	}
 
-	if !context.connection.isRemote {
+	if !browserContext.connection.isRemote {
 		converted.LocalPaths = localPaths
 		converted.LocalDirectory = localDir
 		return converted, nil
@@ -100,8 +103,8 @@ func convertInputFiles(files any, context *browserContextImpl) (*inputFiles, err
 		})
 	}
 
-	ret, err := context.connection.WrapAPICall(func() (any, error) {
-		return context.channel.SendReturnAsDict("createTempFiles", params)
+	ret, err := browserContext.connection.wrapAPICall(nil, "", nil, ctx, func(ctx context.Context) (any, error) {
+		return browserContext.channel.within(ctx).SendReturnAsDict("createTempFiles", params)
 	}, true)
 	if err != nil {
 		return nil, err
@@ -112,7 +115,7 @@ func convertInputFiles(files any, context *browserContextImpl) (*inputFiles, err
 	items := result["writableStreams"].([]any)
 	for i := 0; i < len(allFiles); i++ {
 		stream := fromChannel(items[i]).(*writableStream)
-		if err := stream.Copy(allFiles[i]); err != nil {
+		if err := stream.Copy(ctx, allFiles[i]); err != nil {
 			return nil, err
 		}
 		streams = append(streams, stream.channel)
@@ -0,0 +1,80 @@
+package playwright
+
+import (
+	"context"
+	"time"
+)
+
+// Instrumentation observes every API call, e.g. to record tracing spans,
+// metrics or audit logs of browser actions. Set it with
+// [RunOptions.Instrumentation].
+//
+// An API call is reported once, however many calls to the driver it makes,
+// including the calls that make none, like waiting for an event. The calls
+// made by the callback of an Expect method are API calls of their own, within
+// the Expect call. Its methods are called synchronously on the goroutine
+// making the API call, so they must not block or call Playwright APIs.
+type Instrumentation interface {
+	// OnCallStart is called right before the API call starts.
+	OnCallStart(call *APICall)
+	// OnCallEnd is called with the same call once it completed, with its
+	// Duration and Err set.
+	OnCallEnd(call *APICall)
+}
+
+// APICall describes an API call for [Instrumentation].
+type APICall struct {
+	// APIName is the API method, e.g. "Page.Goto". It is empty for Internal
+	// calls.
+	APIName string
+	// Internal is true for the calls playwright-go makes on its own.
+	Internal bool
+	// ObjectType and GUID identify the object of the call, e.g. the "Frame"
+	// object with guid "frame@...". They are empty if it is not known.
+	ObjectType string
+	GUID       string
+	// Method is the protocol method, e.g. "goto", if the API call is a single
+	// call to the driver, and empty otherwise.
+	Method string
+	// Params of the protocol method, if Method is set. They must not be
+	// modified.
+	Params map[string]any
+	// File and Line are the call site of the API method in user code, if
+	// known.
+	File string
+	Line int
+	// Context is the context of the [ContextBinder.WithContext] view that
+	// made the call, or nil.
+	Context   context.Context
+	StartTime time.Time
+	Duration  time.Duration
+	Err       error
+	// State is free for the Instrumentation to use, e.g. to keep the span
+	// started in OnCallStart until OnCallEnd.
+	State any
+}
+
+func newAPICall(metadata map[string]any, object *channel, method string, params map[string]any, ctx context.Context) *APICall {
+	call := &APICall{
+		Internal:  true,
+		Method:    method,
+		Params:    params,
+		Context:   ctx,
+		StartTime: time.Now(),
+	}
+	if object != nil {
+		call.ObjectType = object.owner.objectType
+		call.GUID = object.guid
+	}
+	if isInternal, ok := metadata["isInternal"].(bool); ok {
+		call.Internal = isInternal
+	}
+	if !call.Internal {
+		call.APIName, _ = metadata["apiName"].(string)
+	}
+	if location, ok := metadata["location"].(map[string]any); ok {
+		call.File, _ = location["file"].(string)
+		call.Line, _ = location["line"].(int)
+	}
+	return call
+}
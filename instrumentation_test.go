@@ -0,0 +1,139 @@
+package playwright
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingInstrumentation struct {
+	mu     sync.Mutex
+	starts []APICall
+	ends   []APICall
+}
+
+func (r *recordingInstrumentation) OnCallStart(call *APICall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	call.State = len(r.starts)
+	r.starts = append(r.starts, *call)
+}
+
+func (r *recordingInstrumentation) OnCallEnd(call *APICall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ends = append(r.ends, *call)
+}
+
+func TestInstrumentation(t *testing.T) {
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		reply := &message{ID: int(msg["id"].(uint32))}
+		if msg["method"] == "title" {
+			reply.Result = map[string]any{"value": "Example"}
+		} else {
+			reply.Error = &struct {
+				Error Error `json:"error"`
+			}{Error: Error{Name: "Error", Message: "boom"}}
+		}
+		t.deliver(reply)
+	})
+	c := newFakeConnection(t, transport)
+	instrumentation := &recordingInstrumentation{}
+	c.instrumentation = instrumentation
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+
+	title, err := page.channel.Send("title")
+	require.NoError(t, err)
+	require.Equal(t, "Example", title)
+	_, err = page.channel.Send("click", map[string]any{"selector": "#missing"})
+	require.ErrorContains(t, err, "boom")
+
+	require.Len(t, instrumentation.starts, 2)
+	require.Len(t, instrumentation.ends, 2)
+	start, end := instrumentation.starts[0], instrumentation.ends[0]
+	require.Equal(t, "Page", start.ObjectType)
+	require.Equal(t, "page@1", start.GUID)
+	require.Equal(t, "title", start.Method)
+	require.False(t, start.Internal)
+	require.NotEmpty(t, start.APIName)
+	require.Zero(t, start.Duration)
+	require.Equal(t, 0, end.State)
+	require.Positive(t, end.Duration)
+	require.NoError(t, end.Err)
+
+	start, end = instrumentation.starts[1], instrumentation.ends[1]
+	require.Equal(t, "click", start.Method)
+	require.Equal(t, map[string]any{"selector": "#missing"}, start.Params)
+	require.Equal(t, 1, end.State)
+	require.ErrorContains(t, end.Err, "boom")
+}
+
+func TestInstrumentationReportsOneCallPerAPICall(t *testing.T) {
+	var mu sync.Mutex
+	sent := 0
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		mu.Lock()
+		sent++
+		mu.Unlock()
+		t.deliver(&message{ID: int(msg["id"].(uint32))})
+	})
+	c := newFakeConnection(t, transport)
+	instrumentation := &recordingInstrumentation{}
+	c.instrumentation = instrumentation
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+
+	require.NoError(t, page.channel.instrumentAPICall(func(ctx context.Context) error {
+		if _, err := page.channel.within(ctx).Send("first"); err != nil {
+			return err
+		}
+		// A call made meanwhile on another goroutine is an API call of its own.
+		done := make(chan error)
+		go func() {
+			_, err := page.channel.Send("other")
+			done <- err
+		}()
+		if err := <-done; err != nil {
+			return err
+		}
+		_, err := page.channel.within(ctx).Send("second")
+		return err
+	}))
+	require.NoError(t, page.channel.instrumentAPICall(func(context.Context) error { return nil }))
+
+	require.Equal(t, 3, sent)
+	require.Len(t, instrumentation.starts, 3)
+	require.Len(t, instrumentation.ends, 3)
+	require.Equal(t, "other", instrumentation.starts[1].Method)
+	for _, i := range []int{0, 2} {
+		call := instrumentation.starts[i]
+		require.Equal(t, "Page", call.ObjectType)
+		require.Equal(t, "page@1", call.GUID)
+		require.Empty(t, call.Method)
+		require.NotEmpty(t, call.APIName)
+	}
+}
+
+func TestInstrumentationPageCloseIsOneCall(t *testing.T) {
+	var methods []string
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		methods = append(methods, msg["method"].(string))
+		t.deliver(&message{ID: int(msg["id"].(uint32))})
+	})
+	c := newFakeConnection(t, transport)
+	instrumentation := &recordingInstrumentation{}
+	c.instrumentation = instrumentation
+	page := &pageImpl{pageState: &pageState{}}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+	page.channel = page.channelOwner.channel
+
+	require.NoError(t, page.Close(PageCloseOptions{RunBeforeUnload: Bool(true)}))
+	require.Equal(t, []string{"runBeforeUnload"}, methods)
+	require.Len(t, instrumentation.starts, 1)
+	require.Len(t, instrumentation.ends, 1)
+	require.Equal(t, "Page.Close", instrumentation.starts[0].APIName)
+	require.Empty(t, instrumentation.starts[0].Method)
+}
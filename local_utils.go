@@ -1,9 +1,14 @@
 package playwright
 
 import (
+	"archive/zip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 )
 
 type localUtilsImpl struct {
@@ -11,6 +16,11 @@ type localUtilsImpl struct {
 	Devices map[string]*DeviceDescriptor
 }
 
+// errLocalUtilsUnavailable is returned by the features that need the driver's
+// LocalUtils on a connection made without a driver (see [Connect]), where
+// connection.localUtils is nil. Its methods accept a nil receiver for this.
+var errLocalUtilsUnavailable = fmt.Errorf("%w: not available when connected without a local driver", ErrPlaywright)
+
 type (
 	localUtilsZipOptions struct {
 		ZipFile           string   `json:"zipFile"`
@@ -40,11 +50,22 @@ type (
 	}
 )
 
-func (l *localUtilsImpl) Zip(options localUtilsZipOptions) (any, error) {
-	return l.channel.Send("zip", options)
+func (l *localUtilsImpl) Zip(ctx context.Context, options localUtilsZipOptions) (any, error) {
+	if l == nil {
+		// Appending source stacks to a trace saved from the server is
+		// skipped; the trace itself is complete.
+		if options.Mode == "append" {
+			return nil, nil
+		}
+		return nil, errLocalUtilsUnavailable
+	}
+	return l.channel.within(ctx).Send("zip", options)
 }
 
 func (l *localUtilsImpl) HarOpen(file string) (string, error) {
+	if l == nil {
+		return "", errLocalUtilsUnavailable
+	}
 	result, err := l.channel.SendReturnAsDict("harOpen", []map[string]any{
 		{
 			"file": file,
@@ -62,6 +83,9 @@ func (l *localUtilsImpl) HarOpen(file string) (string, error) {
 }
 
 func (l *localUtilsImpl) HarLookup(option harLookupOptions) (*harLookupResult, error) {
+	if l == nil {
+		return nil, errLocalUtilsUnavailable
+	}
 	overrides := make(map[string]any)
 	overrides["harId"] = option.HarId
 	overrides["url"] = option.URL
@@ -102,6 +126,9 @@ func (l *localUtilsImpl) HarLookup(option harLookupOptions) (*harLookupResult, e
 }
 
 func (l *localUtilsImpl) HarClose(harId string) error {
+	if l == nil {
+		return errLocalUtilsUnavailable
+	}
 	_, err := l.channel.Send("harClose", []map[string]any{
 		{
 			"harId": harId,
@@ -110,7 +137,14 @@ func (l *localUtilsImpl) HarClose(harId string) error {
 	return err
 }
 
-func (l *localUtilsImpl) HarUnzip(zipFile, harFile string, resourcesDir ...*string) error {
+func (l *localUtilsImpl) HarUnzip(ctx context.Context, zipFile, harFile string, resourcesDir ...*string) error {
+	if l == nil {
+		var dir *string
+		if len(resourcesDir) > 0 {
+			dir = resourcesDir[0]
+		}
+		return harUnzip(zipFile, harFile, dir)
+	}
 	params := map[string]any{
 		"zipFile": zipFile,
 		"harFile": harFile,
@@ -118,33 +152,42 @@ func (l *localUtilsImpl) HarUnzip(zipFile, harFile string, resourcesDir ...*stri
 	if len(resourcesDir) > 0 && resourcesDir[0] != nil {
 		params["resourcesDir"] = *resourcesDir[0]
 	}
-	_, err := l.channel.Send("harUnzip", []map[string]any{params})
+	_, err := l.channel.within(ctx).Send("harUnzip", []map[string]any{params})
 	return err
 }
 
-func (l *localUtilsImpl) TracingStarted(traceName string, live bool, tracesDir ...string) (string, error) {
+func (l *localUtilsImpl) TracingStarted(ctx context.Context, traceName string, live bool, tracesDir ...string) (string, error) {
+	if l == nil {
+		return "", nil // no source stacks are collected
+	}
 	overrides := make(map[string]any)
 	overrides["traceName"] = traceName
 	overrides["live"] = live
 	if len(tracesDir) > 0 {
 		overrides["tracesDir"] = tracesDir[0]
 	}
-	stacksId, err := l.channel.Send("tracingStarted", overrides)
+	stacksId, err := l.channel.within(ctx).Send("tracingStarted", overrides)
 	if stacksId == nil {
 		return "", err
 	}
 	return stacksId.(string), err
 }
 
-func (l *localUtilsImpl) TraceDiscarded(stacksId string) error {
-	_, err := l.channel.Send("traceDiscarded", map[string]any{
+func (l *localUtilsImpl) TraceDiscarded(ctx context.Context, stacksId string) error {
+	if l == nil {
+		return nil
+	}
+	_, err := l.channel.within(ctx).Send("traceDiscarded", map[string]any{
 		"stacksId": stacksId,
 	})
 	return err
 }
 
-func (l *localUtilsImpl) AddStackToTracingNoReply(id uint32, stack []map[string]any) {
-	l.channel.SendNoReply("addStackToTracingNoReply", map[string]any{
+func (l *localUtilsImpl) AddStackToTracingNoReply(ctx context.Context, id uint32, stack []map[string]any) {
+	if l == nil {
+		return
+	}
+	l.channel.within(ctx).SendNoReply("addStackToTracingNoReply", map[string]any{
 		"callData": map[string]any{
 			"id":    id,
 			"stack": stack,
@@ -152,6 +195,42 @@ func (l *localUtilsImpl) AddStackToTracingNoReply(id uint32, stack []map[string]
 	})
 }
 
+// harUnzip is LocalUtils.harUnzip in Go: it extracts har.har of a HAR archive
+// saved from a remote browser to harFile, and the other entries (the response
+// bodies) next to it or into resourcesDir, then removes the archive.
+func harUnzip(zipFile, harFile string, resourcesDir *string) error {
+	dir := filepath.Dir(zipFile)
+	if resourcesDir != nil {
+		dir = *resourcesDir
+	}
+	archive, err := zip.OpenReader(zipFile)
+	if err != nil {
+		return fmt.Errorf("could not open HAR archive: %w", err)
+	}
+	for _, entry := range archive.File {
+		diskPath := harFile
+		if entry.Name != "har.har" {
+			if diskPath, err = safeJoin(dir, entry.Name); err != nil {
+				break
+			}
+		}
+		var rc io.ReadCloser
+		if rc, err = entry.Open(); err != nil {
+			break
+		}
+		err = writeFileFromReader(diskPath, rc, 0o644)
+		rc.Close() //nolint:errcheck
+		if err != nil {
+			break
+		}
+	}
+	archive.Close() //nolint:errcheck
+	if err != nil {
+		return fmt.Errorf("could not extract HAR archive: %w", err)
+	}
+	return os.Remove(zipFile)
+}
+
 func newLocalUtils(parent *channelOwner, objectType string, guid string, initializer map[string]any) *localUtilsImpl {
 	l := &localUtilsImpl{
 		Devices: make(map[string]*DeviceDescriptor),
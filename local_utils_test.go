@@ -0,0 +1,43 @@
+package playwright
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarUnzipWithoutDriver(t *testing.T) {
+	dir := t.TempDir()
+	zipFile := filepath.Join(dir, "har.zip")
+	file, err := os.Create(zipFile)
+	require.NoError(t, err)
+	archive := zip.NewWriter(file)
+	for name, content := range map[string]string{"har.har": `{"log":{}}`, "0123.html": "<p>body</p>"} {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, file.Close())
+
+	var localUtils *localUtilsImpl
+	resourcesDir := filepath.Join(dir, "resources")
+	harFile := filepath.Join(dir, "out.har")
+	require.NoError(t, localUtils.HarUnzip(context.Background(), zipFile, harFile, &resourcesDir))
+
+	data, err := os.ReadFile(harFile)
+	require.NoError(t, err)
+	require.Equal(t, `{"log":{}}`, string(data))
+	data, err = os.ReadFile(filepath.Join(resourcesDir, "0123.html"))
+	require.NoError(t, err)
+	require.Equal(t, "<p>body</p>", string(data))
+	require.NoFileExists(t, zipFile)
+
+	_, err = localUtils.HarOpen(harFile)
+	require.ErrorIs(t, err, ErrPlaywright)
+}
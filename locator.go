@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -35,7 +36,7 @@ func newLocator(frame *frameImpl, selector string, options ...LocatorOptions) *l
 	}
 	if option.Has != nil {
 		has := option.Has.(*locatorImpl)
-		if frame != has.frame {
+		if frame.frameState != has.frame.frameState {
 			locator.err = errors.Join(locator.err, ErrLocatorNotSameFrame)
 		} else {
 			selector += fmt.Sprintf(` >> internal:has=%s`, escapeText(has.selector))
@@ -43,7 +44,7 @@ func newLocator(frame *frameImpl, selector string, options ...LocatorOptions) *l
 	}
 	if option.HasNot != nil {
 		hasNot := option.HasNot.(*locatorImpl)
-		if frame != hasNot.frame {
+		if frame.frameState != hasNot.frame.frameState {
 			locator.err = errors.Join(locator.err, ErrLocatorNotSameFrame)
 		} else {
 			selector += fmt.Sprintf(` >> internal:has-not=%s`, escapeText(hasNot.selector))
@@ -59,7 +60,7 @@ func newLocator(frame *frameImpl, selector string, options ...LocatorOptions) *l
 }
 
 func (l *locatorImpl) equals(locator Locator) bool {
-	return l.frame == locator.(*locatorImpl).frame && l.err == locator.(*locatorImpl).err && l.selector == locator.(*locatorImpl).selector
+	return l.frame.frameState == locator.(*locatorImpl).frame.frameState && l.err == locator.(*locatorImpl).err && l.selector == locator.(*locatorImpl).selector
 }
 
 // withError returns a copy of the locator carrying an additional error, without
@@ -74,6 +75,15 @@ func (l *locatorImpl) withError(err error) *locatorImpl {
 	}
 }
 
+func (l *locatorImpl) WithContext(ctx context.Context) Locator {
+	return &locatorImpl{
+		frame:    l.frame.withContext(ctx),
+		selector: l.selector,
+		options:  l.options,
+		err:      l.err,
+	}
+}
+
 func (l *locatorImpl) Err() error {
 	return l.err
 }
@@ -133,14 +143,14 @@ func (l *locatorImpl) AllTextContents() ([]string, error) {
 }
 
 func (l *locatorImpl) And(locator Locator) Locator {
-	if l.frame != locator.(*locatorImpl).frame {
+	if l.frame.frameState != locator.(*locatorImpl).frame.frameState {
 		return l.withError(ErrLocatorNotSameFrame)
 	}
 	return newLocator(l.frame, l.selector+` >> internal:and=`+escapeText(locator.(*locatorImpl).selector))
 }
 
 func (l *locatorImpl) Or(locator Locator) Locator {
-	if l.frame != locator.(*locatorImpl).frame {
+	if l.frame.frameState != locator.(*locatorImpl).frame.frameState {
 		return l.withError(ErrLocatorNotSameFrame)
 	}
 	return newLocator(l.frame, l.selector+` >> internal:or=`+escapeText(locator.(*locatorImpl).selector))
@@ -299,6 +309,12 @@ func (l *locatorImpl) DragTo(target Locator, options ...LocatorDragToOptions) er
 }
 
 func (l *locatorImpl) Drop(payload Payload, options ...LocatorDropOptions) error {
+	return l.frame.channel.instrumentAPICall(func(ctx context.Context) error {
+		return l.drop(ctx, payload, options...)
+	})
+}
+
+func (l *locatorImpl) drop(ctx context.Context, payload Payload, options ...LocatorDropOptions) error {
 	if l.err != nil {
 		return l.err
 	}
@@ -307,7 +323,7 @@ func (l *locatorImpl) Drop(payload Payload, options ...LocatorDropOptions) error
 		"strict":   true,
 	}
 	if payload.Files != nil {
-		converted, err := convertInputFiles(payload.Files, l.frame.page.browserContext)
+		converted, err := convertInputFiles(ctx, payload.Files, l.frame.page.browserContext)
 		if err != nil {
 			return err
 		}
@@ -342,7 +358,7 @@ func (l *locatorImpl) Drop(payload Payload, options ...LocatorDropOptions) error
 	if _, ok := params["timeout"]; !ok {
 		params["timeout"] = float64(30000) // default 30s, required in Playwright v1.57+
 	}
-	_, err := l.frame.channel.Send("drop", params)
+	_, err := l.frame.channel.within(ctx).Send("drop", params)
 	return err
 }
 
@@ -714,7 +730,7 @@ func (l *locatorImpl) Locator(selectorOrLocator any, options ...LocatorLocatorOp
 	}
 	locator, ok := selectorOrLocator.(*locatorImpl)
 	if ok {
-		if l.frame != locator.frame {
+		if l.frame.frameState != locator.frame.frameState {
 			return l.withError(ErrLocatorNotSameFrame)
 		}
 		return newLocator(
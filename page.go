@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -14,6 +15,14 @@ import (
 )
 
 type pageImpl struct {
+	*pageState
+	// channel and mainFrame are bound to a context in the views returned by
+	// WithContext, which share the pageState of the page.
+	channel   *channel
+	mainFrame Frame
+}
+
+type pageState struct {
 	channelOwner
 	isClosed        bool
 	closedOrCrashed chan error
@@ -25,7 +34,6 @@ type pageImpl struct {
 	browserContext  *browserContextImpl
 	frames          []Frame
 	workers         []Worker
-	mainFrame       Frame
 	routes          []*routeHandlerEntry
 	webSocketRoutes []*webSocketRouteHandler
 	viewportSize    *Size
@@ -39,6 +47,14 @@ type pageImpl struct {
 	sessionStorage  *webStorageImpl
 }
 
+func (p *pageImpl) WithContext(ctx context.Context) Page {
+	return &pageImpl{
+		pageState: p.pageState,
+		channel:   p.channelOwner.channel.withContext(ctx),
+		mainFrame: p.mainFrame.(*frameImpl).withContext(ctx),
+	}
+}
+
 func (p *pageImpl) LocalStorage() WebStorage {
 	return p.localStorage
 }
@@ -70,7 +86,7 @@ func (p *pageImpl) AddLocatorHandler(locator Locator, handler func(Locator), opt
 	}
 
 	loc := locator.(*locatorImpl)
-	if loc.frame != p.mainFrame {
+	if loc.frame.frameState != p.mainFrame.(*frameImpl).frameState {
 		return errors.New("locator must belong to the main frame of this page")
 	}
 	uid, err := p.channel.Send("registerLocatorHandler", map[string]any{
@@ -135,6 +151,12 @@ func (b *pageImpl) Clock() Clock {
 }
 
 func (p *pageImpl) Close(options ...PageCloseOptions) error {
+	return p.channel.instrumentAPICall(func(ctx context.Context) error {
+		return p.close(ctx, options...)
+	})
+}
+
+func (p *pageImpl) close(ctx context.Context, options ...PageCloseOptions) error {
 	runBeforeUnload := false
 	if len(options) == 1 {
 		p.closeReason = options[0].Reason
@@ -148,17 +170,17 @@ func (p *pageImpl) Close(options ...PageCloseOptions) error {
 	}
 	var err error
 	if p.ownedContext != nil {
-		err = p.ownedContext.Close()
+		err = p.ownedContext.(*browserContextImpl).close(ctx)
 	} else if runBeforeUnload {
 		// Upstream split Page.close into close and runBeforeUnload; the latter
 		// takes no params and the close params no longer carry runBeforeUnload.
-		_, err = p.channel.Send("runBeforeUnload")
+		_, err = p.channel.within(ctx).Send("runBeforeUnload")
 	} else {
 		params := map[string]any{}
 		if p.closeReason != nil {
 			params["reason"] = *p.closeReason
 		}
-		_, err = p.channel.Send("close", params)
+		_, err = p.channel.within(ctx).Send("close", params)
 	}
 	if errors.Is(err, ErrTargetClosed) && !runBeforeUnload {
 		return nil
@@ -602,7 +624,7 @@ func (p *pageImpl) waiterForEvent(event string, options ...PageWaitForEventOptio
 		}
 		predicate = options[0].Predicate
 	}
-	waiter := newWaiter().WithTimeout(timeout)
+	waiter := newWaiter().WithChannel(p.channel).WithTimeout(timeout)
 	// Don't reject on the very event being awaited.
 	if event != "crash" {
 		waiter.RejectOnEvent(p, "crash", errors.New("page crashed"))
@@ -632,7 +654,7 @@ func (p *pageImpl) waiterForRequest(url any, options ...PageExpectRequestOptions
 		return true
 	}
 
-	waiter := newWaiter().WithTimeout(*option.Timeout)
+	waiter := newWaiter().WithChannel(p.channel).WithTimeout(*option.Timeout)
 	// Fail fast if the page crashes or closes while waiting, matching upstream.
 	waiter.RejectOnEvent(p, "crash", errors.New("page crashed"))
 	waiter.RejectOnEvent(p, "close", p.closeErrorWithReason())
@@ -658,7 +680,7 @@ func (p *pageImpl) waiterForResponse(url any, options ...PageExpectResponseOptio
 		return true
 	}
 
-	waiter := newWaiter().WithTimeout(*option.Timeout)
+	waiter := newWaiter().WithChannel(p.channel).WithTimeout(*option.Timeout)
 	// Fail fast if the page crashes or closes while waiting, matching upstream.
 	waiter.RejectOnEvent(p, "crash", errors.New("page crashed"))
 	waiter.RejectOnEvent(p, "close", p.closeErrorWithReason())
@@ -907,15 +929,16 @@ func newPage(parent *channelOwner, objectType string, guid string, initializer m
 		viewportSize.Height = int(initializer["viewportSize"].(map[string]any)["height"].(float64))
 		viewportSize.Width = int(initializer["viewportSize"].(map[string]any)["width"].(float64))
 	}
-	bt := &pageImpl{
+	bt := &pageImpl{pageState: &pageState{
 		workers:         make([]Worker, 0),
 		routes:          make([]*routeHandlerEntry, 0),
 		bindings:        safe.NewSyncMap[string, BindingCallFunction](),
 		viewportSize:    viewportSize,
 		harRouters:      make([]*harRouter, 0),
 		locatorHandlers: make(map[float64]*locatorHandlerEntry, 0),
-	}
+	}}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
+	bt.channel = bt.channelOwner.channel
 	if closed, ok := initializer["isClosed"].(bool); ok {
 		bt.isClosed = closed
 	}
@@ -1002,18 +1025,18 @@ func newPage(parent *channelOwner, objectType string, guid string, initializer m
 		bt.onWorker(fromChannel(ev["worker"]).(*workerImpl))
 	})
 	bt.closedOrCrashed = make(chan error, 1)
-	bt.OnClose(func(Page) {
+	subscribeInternal(bt, "close", func() {
 		select {
 		case bt.closedOrCrashed <- bt.closeErrorWithReason():
 		default:
 		}
-	})
-	bt.OnCrash(func(Page) {
+	}, false)
+	subscribeInternal(bt, "crash", func() {
 		select {
 		case bt.closedOrCrashed <- ErrTargetClosed:
 		default:
 		}
-	})
+	}, false)
 	bt.setEventSubscriptionMapping(map[string]string{
 		"console":         "console",
 		"dialog":          "dialog",
@@ -1429,80 +1452,80 @@ func (p *pageImpl) FrameLocator(selector string) FrameLocator {
 	return p.mainFrame.FrameLocator(selector)
 }
 
-func (p *pageImpl) OnClose(fn func(Page)) {
-	p.On("close", fn)
+func (p *pageImpl) OnClose(fn func(Page)) Subscription {
+	return p.On("close", fn)
 }
 
-func (p *pageImpl) OnConsole(fn func(ConsoleMessage)) {
-	p.On("console", fn)
+func (p *pageImpl) OnConsole(fn func(ConsoleMessage)) Subscription {
+	return p.On("console", fn)
 }
 
-func (p *pageImpl) OnCrash(fn func(Page)) {
-	p.On("crash", fn)
+func (p *pageImpl) OnCrash(fn func(Page)) Subscription {
+	return p.On("crash", fn)
 }
 
-func (p *pageImpl) OnDialog(fn func(Dialog)) {
-	p.On("dialog", fn)
+func (p *pageImpl) OnDialog(fn func(Dialog)) Subscription {
+	return p.On("dialog", fn)
 }
 
-func (p *pageImpl) OnDOMContentLoaded(fn func(Page)) {
-	p.On("domcontentloaded", fn)
+func (p *pageImpl) OnDOMContentLoaded(fn func(Page)) Subscription {
+	return p.On("domcontentloaded", fn)
 }
 
-func (p *pageImpl) OnDownload(fn func(Download)) {
-	p.On("download", fn)
+func (p *pageImpl) OnDownload(fn func(Download)) Subscription {
+	return p.On("download", fn)
 }
 
-func (p *pageImpl) OnFileChooser(fn func(FileChooser)) {
-	p.On("filechooser", fn)
+func (p *pageImpl) OnFileChooser(fn func(FileChooser)) Subscription {
+	return p.On("filechooser", fn)
 }
 
-func (p *pageImpl) OnFrameAttached(fn func(Frame)) {
-	p.On("frameattached", fn)
+func (p *pageImpl) OnFrameAttached(fn func(Frame)) Subscription {
+	return p.On("frameattached", fn)
 }
 
-func (p *pageImpl) OnFrameDetached(fn func(Frame)) {
-	p.On("framedetached", fn)
+func (p *pageImpl) OnFrameDetached(fn func(Frame)) Subscription {
+	return p.On("framedetached", fn)
 }
 
-func (p *pageImpl) OnFrameNavigated(fn func(Frame)) {
-	p.On("framenavigated", fn)
+func (p *pageImpl) OnFrameNavigated(fn func(Frame)) Subscription {
+	return p.On("framenavigated", fn)
 }
 
-func (p *pageImpl) OnLoad(fn func(Page)) {
-	p.On("load", fn)
+func (p *pageImpl) OnLoad(fn func(Page)) Subscription {
+	return p.On("load", fn)
 }
 
-func (p *pageImpl) OnPageError(fn func(error)) {
-	p.On("pageerror", fn)
+func (p *pageImpl) OnPageError(fn func(error)) Subscription {
+	return p.On("pageerror", fn)
 }
 
-func (p *pageImpl) OnPopup(fn func(Page)) {
-	p.On("popup", fn)
+func (p *pageImpl) OnPopup(fn func(Page)) Subscription {
+	return p.On("popup", fn)
 }
 
-func (p *pageImpl) OnRequest(fn func(Request)) {
-	p.On("request", fn)
+func (p *pageImpl) OnRequest(fn func(Request)) Subscription {
+	return p.On("request", fn)
 }
 
-func (p *pageImpl) OnRequestFailed(fn func(Request)) {
-	p.On("requestfailed", fn)
+func (p *pageImpl) OnRequestFailed(fn func(Request)) Subscription {
+	return p.On("requestfailed", fn)
 }
 
-func (p *pageImpl) OnRequestFinished(fn func(Request)) {
-	p.On("requestfinished", fn)
+func (p *pageImpl) OnRequestFinished(fn func(Request)) Subscription {
+	return p.On("requestfinished", fn)
 }
 
-func (p *pageImpl) OnResponse(fn func(Response)) {
-	p.On("response", fn)
+func (p *pageImpl) OnResponse(fn func(Response)) Subscription {
+	return p.On("response", fn)
 }
 
-func (p *pageImpl) OnWebSocket(fn func(WebSocket)) {
-	p.On("websocket", fn)
+func (p *pageImpl) OnWebSocket(fn func(WebSocket)) Subscription {
+	return p.On("websocket", fn)
 }
 
-func (p *pageImpl) OnWorker(fn func(Worker)) {
-	p.On("worker", fn)
+func (p *pageImpl) OnWorker(fn func(Worker)) Subscription {
+	return p.On("worker", fn)
 }
 
 func (p *pageImpl) RequestGC() error {
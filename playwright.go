@@ -30,6 +30,18 @@ func (p *Playwright) Stop() error {
 	return p.connection.Stop()
 }
 
+// OnDisconnected registers fn to be called when the connection to the driver
+// is closed: with nil after [Playwright.Stop], and with the cause when the
+// driver went away on its own. When the driver process exited, the cause wraps
+// a [*DriverExitError] with its exit code and the tail of its stderr. fn is
+// called right away if the connection is already closed.
+//
+// Every Browser, BrowserContext and Page of the instance is gone by then; see
+// [Supervise] to restart the driver automatically.
+func (p *Playwright) OnDisconnected(fn func(error)) {
+	p.connection.onDisconnected(fn)
+}
+
 // Pid returns the process ID of the Playwright driver process, or 0 if not available
 func (p *Playwright) Pid() int {
 	if pt, ok := p.connection.transport.(*pipeTransport); ok {
@@ -0,0 +1,232 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// pollDefaultIntervals are the intervals between attempts in milliseconds; the
+// last one repeats.
+var pollDefaultIntervals = []float64{100, 250, 500, 1000}
+
+// PlaywrightPollAssertions is implemented by [PlaywrightAssertions].
+type PlaywrightPollAssertions interface {
+	// Creates a [PollAssertions] object that retries fn until its result matches, for values computed in Go such as
+	// API responses or database rows.
+	//
+	//  fn: Function returning the value to assert.
+	Poll(fn func() (any, error), options ...PlaywrightAssertionsPollOptions) PollAssertions
+
+	// Retries fn until it returns nil or the assertion timeout elapses. The returned error wraps the last error of fn
+	// and reports the number of attempts.
+	//
+	//  fn: Function to retry.
+	ToPass(fn func() error, options ...PlaywrightAssertionsToPassOptions) error
+}
+
+// PollAssertions retries a Go function until its result matches, see
+// [PlaywrightAssertions.Poll]:
+//
+//	err := expect.Poll(func() (any, error) {
+//		return len(page.Workers()), nil
+//	}).ToEqual(2)
+type PollAssertions interface {
+	// Makes the assertion check for the opposite condition.
+	Not() PollAssertions
+
+	// Ensures the value is deeply equal to expected, see [reflect.DeepEqual].
+	ToEqual(expected any) error
+
+	// Ensures the value contains expected: a substring of a string, an element
+	// of a slice or array, or a key of a map.
+	ToContain(expected any) error
+
+	// Ensures the value, formatted with fmt.Sprint, matches pattern. It fails
+	// right away if pattern is nil.
+	ToMatch(pattern *regexp.Regexp) error
+
+	// Ensures predicate returns true for the value. description is used in the
+	// error message. It fails right away if predicate is nil.
+	ToSatisfy(description string, predicate func(value any) bool) error
+}
+
+type PlaywrightAssertionsPollOptions struct {
+	// Time to retry the assertion for in milliseconds. Defaults to the timeout of the [PlaywrightAssertions].
+	Timeout *float64
+	// Intervals between attempts in milliseconds; the last one repeats. Defaults to `[100, 250, 500, 1000]`.
+	Intervals []float64
+	// Message prepended to the error when the assertion fails.
+	Message *string
+}
+
+type PlaywrightAssertionsToPassOptions struct {
+	// Time to retry fn for in milliseconds. Defaults to the timeout of the [PlaywrightAssertions].
+	Timeout *float64
+	// Intervals between attempts in milliseconds; the last one repeats. Defaults to `[100, 250, 500, 1000]`.
+	Intervals []float64
+}
+
+func (pa *playwrightAssertionsImpl) Poll(fn func() (any, error), options ...PlaywrightAssertionsPollOptions) PollAssertions {
+	var option PlaywrightAssertionsPollOptions
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Timeout == nil {
+		option.Timeout = pa.defaultTimeout
+	}
+	return &pollAssertionsImpl{fn: fn, options: option}
+}
+
+func (pa *playwrightAssertionsImpl) ToPass(fn func() error, options ...PlaywrightAssertionsToPassOptions) error {
+	var option PlaywrightAssertionsToPassOptions
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Timeout == nil {
+		option.Timeout = pa.defaultTimeout
+	}
+	attempts, err := retryUntil(*option.Timeout, option.Intervals, fn)
+	if err != nil {
+		return fmt.Errorf("ToPass failed after %d %s within %vms: %w", attempts, pluralize("attempt", attempts), *option.Timeout, err)
+	}
+	return nil
+}
+
+type pollAssertionsImpl struct {
+	fn      func() (any, error)
+	options PlaywrightAssertionsPollOptions
+	isNot   bool
+}
+
+func (p *pollAssertionsImpl) Not() PollAssertions {
+	return &pollAssertionsImpl{fn: p.fn, options: p.options, isNot: !p.isNot}
+}
+
+func (p *pollAssertionsImpl) ToEqual(expected any) error {
+	return p.expect(func(actual any) (bool, error) {
+		return reflect.DeepEqual(actual, expected), nil
+	}, expected, "Value expected to equal")
+}
+
+func (p *pollAssertionsImpl) ToContain(expected any) error {
+	return p.expect(func(actual any) (bool, error) {
+		return valueContains(actual, expected)
+	}, expected, "Value expected to contain")
+}
+
+func (p *pollAssertionsImpl) ToMatch(pattern *regexp.Regexp) error {
+	if pattern == nil {
+		return errors.New("pattern must not be nil")
+	}
+	return p.expect(func(actual any) (bool, error) {
+		return pattern.MatchString(fmt.Sprint(actual)), nil
+	}, pattern, "Value expected to match")
+}
+
+func (p *pollAssertionsImpl) ToSatisfy(description string, predicate func(value any) bool) error {
+	if predicate == nil {
+		return errors.New("predicate must not be nil")
+	}
+	return p.expect(func(actual any) (bool, error) {
+		return predicate(actual), nil
+	}, description, "Value expected to satisfy")
+}
+
+// expect polls fn until match returns true for its result, or false with
+// Not, or the timeout elapses.
+func (p *pollAssertionsImpl) expect(match func(actual any) (bool, error), expected any, message string) error {
+	if p.isNot {
+		message = strings.ReplaceAll(message, "expected to", "expected not to")
+	}
+	var actual any
+	attempts, err := retryUntil(*p.options.Timeout, p.options.Intervals, func() error {
+		var err error
+		if actual, err = p.fn(); err != nil {
+			return err
+		}
+		matches, err := match(actual)
+		if err != nil {
+			return err
+		}
+		if matches == p.isNot {
+			return errMismatch
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+	if p.options.Message != nil {
+		message = *p.options.Message + "\n" + message
+	}
+	details := fmt.Sprintf("Attempts: %d within %vms", attempts, *p.options.Timeout)
+	if errors.Is(err, errMismatch) {
+		return fmt.Errorf("%s '%v'\nActual value: %v\n%s", message, expected, actual, details)
+	}
+	return fmt.Errorf("%s '%v'\nLast error: %w\n%s", message, expected, err, details)
+}
+
+// errMismatch is returned by a poll attempt whose value did not match.
+var errMismatch = errors.New("value did not match")
+
+// valueContains reports whether actual contains expected, see
+// [PollAssertions.ToContain].
+func valueContains(actual, expected any) (bool, error) {
+	if s, ok := actual.(string); ok {
+		substr, ok := expected.(string)
+		if !ok {
+			return false, fmt.Errorf("expected a string to look for in a string, got %T", expected)
+		}
+		return strings.Contains(s, substr), nil
+	}
+	v := reflect.ValueOf(actual)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if reflect.DeepEqual(v.Index(i).Interface(), expected) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		key := reflect.ValueOf(expected)
+		if !key.IsValid() || !key.Type().AssignableTo(v.Type().Key()) {
+			return false, fmt.Errorf("expected a %s key, got %T", v.Type().Key(), expected)
+		}
+		return v.MapIndex(key).IsValid(), nil
+	}
+	return false, fmt.Errorf("cannot look for a value in %T", actual)
+}
+
+// retryUntil calls attempt until it returns nil or the next interval would
+// end after timeout milliseconds, which is zero for no timeout. It returns the
+// number of attempts and the last error. An attempt in progress is not
+// interrupted by the timeout.
+func retryUntil(timeout float64, intervals []float64, attempt func() error) (int, error) {
+	if len(intervals) == 0 {
+		intervals = pollDefaultIntervals
+	}
+	deadline := time.Now().Add(time.Duration(timeout * float64(time.Millisecond)))
+	for attempts := 1; ; attempts++ {
+		err := attempt()
+		if err == nil {
+			return attempts, nil
+		}
+		interval := time.Duration(intervals[min(attempts-1, len(intervals)-1)] * float64(time.Millisecond))
+		if timeout > 0 && !time.Now().Add(interval).Before(deadline) {
+			return attempts, err
+		}
+		time.Sleep(interval)
+	}
+}
+
+func pluralize(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,89 @@
+package playwright
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollRetriesUntilMatch(t *testing.T) {
+	expect := NewPlaywrightAssertions(1000)
+	calls := 0
+	poll := func() (any, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("not ready")
+		}
+		return []string{"a", "b"}, nil
+	}
+	options := PlaywrightAssertionsPollOptions{Intervals: []float64{1}}
+
+	require.NoError(t, expect.Poll(poll, options).ToEqual([]string{"a", "b"}))
+	require.Equal(t, 3, calls)
+	require.NoError(t, expect.Poll(poll, options).ToContain("b"))
+	require.NoError(t, expect.Poll(poll, options).Not().ToContain("c"))
+	require.NoError(t, expect.Poll(poll, options).ToMatch(regexp.MustCompile(`\[a b\]`)))
+	require.NoError(t, expect.Poll(poll, options).ToSatisfy("two values", func(value any) bool {
+		return len(value.([]string)) == 2
+	}))
+	require.NoError(t, expect.Poll(func() (any, error) {
+		return map[string]int{"rows": 1}, nil
+	}).ToContain("rows"))
+	require.NoError(t, expect.Poll(func() (any, error) {
+		return "hello world", nil
+	}).ToContain("world"))
+}
+
+func TestPollReportsLastValueAndAttempts(t *testing.T) {
+	expect := NewPlaywrightAssertions(50)
+	calls := 0
+	err := expect.Poll(func() (any, error) {
+		calls++
+		return calls, nil
+	}, PlaywrightAssertionsPollOptions{
+		Intervals: []float64{10},
+		Message:   String("row count"),
+	}).ToEqual(0)
+	require.Error(t, err)
+	require.GreaterOrEqual(t, calls, 2)
+	require.Contains(t, err.Error(), "row count\nValue expected to equal '0'")
+	require.Contains(t, err.Error(), "Actual value: "+strconv.Itoa(calls))
+	require.Contains(t, err.Error(), "Attempts: "+strconv.Itoa(calls)+" within 50ms")
+
+	boom := errors.New("boom")
+	err = expect.Poll(func() (any, error) { return nil, boom }).Not().ToEqual(1)
+	require.ErrorIs(t, err, boom)
+	require.Contains(t, err.Error(), "Value expected not to equal '1'\nLast error: boom")
+}
+
+func TestToPass(t *testing.T) {
+	expect := NewPlaywrightAssertions(1000)
+	calls := 0
+	require.NoError(t, expect.ToPass(func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, PlaywrightAssertionsToPassOptions{Intervals: []float64{1}}))
+	require.Equal(t, 3, calls)
+
+	boom := errors.New("boom")
+	calls = 0
+	err := expect.ToPass(func() error {
+		calls++
+		return boom
+	}, PlaywrightAssertionsToPassOptions{Timeout: Float(30), Intervals: []float64{5, 10}})
+	require.ErrorIs(t, err, boom)
+	require.Contains(t, err.Error(), "ToPass failed after "+strconv.Itoa(calls)+" attempts within 30ms: boom")
+}
+
+func TestPollRejectsNilArguments(t *testing.T) {
+	expect := NewPlaywrightAssertions(50)
+	poll := expect.Poll(func() (any, error) { return "value", nil })
+	require.EqualError(t, poll.ToMatch(nil), "pattern must not be nil")
+	require.EqualError(t, poll.Not().ToSatisfy("anything", nil), "predicate must not be nil")
+}
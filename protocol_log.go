@@ -0,0 +1,200 @@
+package playwright
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// ProtocolLogOptions configures the logging of the protocol messages
+// exchanged with the driver. They are logged to [RunOptions.Logger] at debug
+// level, so they are only written when its handler enables that level.
+type ProtocolLogOptions struct {
+	// Filter selects the messages to log by the type of the object they are
+	// for ("Page", "Frame", "BrowserContext", ...) and their method, which is
+	// the event name for events. Replies are logged when their call is. All
+	// messages are logged when nil.
+	Filter func(objectType, method string) bool
+	// Redactions are the rules for hiding values in the logged params and
+	// results. Defaults to [DefaultProtocolRedactions]; set an empty slice to
+	// log messages as they are.
+	Redactions []ProtocolRedaction
+}
+
+// ProtocolRedaction hides the value of the fields named Key, at any depth of
+// the params or result of a message. Strings, numbers and booleans in it are
+// replaced with "[REDACTED]", except those of "name" fields, so that header
+// and cookie names stay visible.
+type ProtocolRedaction struct {
+	// Method restricts the rule to the calls and events of this name.
+	Method string
+	Key    string
+}
+
+// DefaultProtocolRedactions hide credentials: header values, cookies, request
+// bodies, passwords and the values typed with fill.
+var DefaultProtocolRedactions = []ProtocolRedaction{
+	{Key: "headers"},
+	{Key: "extraHTTPHeaders"},
+	{Key: "cookies"},
+	{Key: "postData"},
+	{Key: "jsonData"},
+	{Key: "formData"},
+	{Key: "multipartData"},
+	{Key: "password"},
+	{Method: "fill", Key: "value"},
+}
+
+const redactedValue = "[REDACTED]"
+
+// protocolLogger logs the messages of a connection.
+type protocolLogger struct {
+	logger  *slog.Logger
+	options ProtocolLogOptions
+}
+
+// newProtocolLogger returns the protocol logger of a connection. For
+// compatibility, DEBUGP=1 logs every message to stdout.
+func newProtocolLogger(l *slog.Logger, options *ProtocolLogOptions) *protocolLogger {
+	if os.Getenv("DEBUGP") != "" {
+		l = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	p := &protocolLogger{logger: l}
+	if options != nil {
+		p.options = *options
+	}
+	if p.options.Redactions == nil {
+		p.options.Redactions = DefaultProtocolRedactions
+	}
+	return p
+}
+
+func (p *protocolLogger) enabled(objectType, method string) bool {
+	if p == nil || !p.logger.Enabled(context.Background(), slog.LevelDebug) {
+		return false
+	}
+	return p.options.Filter == nil || p.options.Filter(objectType, method)
+}
+
+func (p *protocolLogger) send(objectType string, msg map[string]any) {
+	method, _ := msg["method"].(string)
+	if !p.enabled(objectType, method) {
+		return
+	}
+	size := 0
+	if data, err := json.Marshal(msg); err == nil {
+		size = len(data)
+	}
+	p.logger.Debug("protocol",
+		slog.String("direction", ProtocolSend),
+		slog.String("objectType", objectType),
+		slog.Any("guid", msg["guid"]),
+		slog.String("method", method),
+		slog.Any("id", msg["id"]),
+		slog.Int("size", size),
+		slog.Any("params", p.redact(method, msg["params"])),
+	)
+}
+
+func (p *protocolLogger) reply(cb *protocolCallback, msg *message) {
+	if !p.enabled(cb.objectType, cb.method) {
+		return
+	}
+	attrs := []any{
+		slog.String("direction", ProtocolReceive),
+		slog.String("objectType", cb.objectType),
+		slog.String("guid", cb.guid),
+		slog.String("method", cb.method),
+		slog.Int("id", msg.ID),
+		slog.Int("size", msg.size),
+		slog.Duration("duration", time.Since(cb.sentAt)),
+	}
+	if msg.Error != nil {
+		attrs = append(attrs, slog.String("error", msg.Error.Error.Message))
+	} else {
+		attrs = append(attrs, slog.Any("result", p.redact(cb.method, msg.Result)))
+	}
+	p.logger.Debug("protocol", attrs...)
+}
+
+func (p *protocolLogger) event(objectType string, msg *message) {
+	if !p.enabled(objectType, msg.Method) {
+		return
+	}
+	p.logger.Debug("protocol",
+		slog.String("direction", ProtocolReceive),
+		slog.String("objectType", objectType),
+		slog.String("guid", msg.GUID),
+		slog.String("method", msg.Method),
+		slog.Int("size", msg.size),
+		slog.Any("params", p.redact(msg.Method, msg.Params)),
+	)
+}
+
+// redact returns a copy of value with the fields matching the redaction rules
+// for method hidden.
+func (p *protocolLogger) redact(method string, value any) any {
+	var keys map[string]bool
+	for _, rule := range p.options.Redactions {
+		if rule.Method == "" || rule.Method == method {
+			if keys == nil {
+				keys = map[string]bool{}
+			}
+			keys[rule.Key] = true
+		}
+	}
+	if keys == nil {
+		return value
+	}
+	return redactFields(value, keys)
+}
+
+func redactFields(value any, keys map[string]bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, field := range v {
+			if keys[key] {
+				redacted[key] = redactAll(field)
+			} else {
+				redacted[key] = redactFields(field, keys)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactFields(item, keys)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func redactAll(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, field := range v {
+			if key == "name" {
+				redacted[key] = field
+			} else {
+				redacted[key] = redactAll(field)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactAll(item)
+		}
+		return redacted
+	default:
+		return redactedValue
+	}
+}
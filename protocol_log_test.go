@@ -0,0 +1,107 @@
+package playwright
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestProtocolLogRedactsCredentials(t *testing.T) {
+	t.Setenv("DEBUGP", "")
+	var buf bytes.Buffer
+	l := newProtocolLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), nil)
+
+	params := map[string]any{"selector": "#password", "value": "hunter2"}
+	l.send("Frame", map[string]any{"id": 3, "guid": "frame@1", "method": "fill", "params": params})
+	require.Equal(t, "hunter2", params["value"])
+	l.event("Request", &message{GUID: "request@1", Method: "response", size: 42, Params: map[string]any{
+		"headers":  []any{map[string]any{"name": "Authorization", "value": "Bearer token"}},
+		"postData": "c2VjcmV0",
+		"url":      "https://example.com",
+	}})
+	cb := &protocolCallback{objectType: "BrowserContext", guid: "browser-context@1", method: "cookies", sentAt: time.Now()}
+	l.reply(cb, &message{ID: 4, Result: map[string]any{
+		"cookies": []any{map[string]any{"name": "session", "value": "abc", "httpOnly": true}},
+	}})
+	l.send("APIRequestContext", map[string]any{"id": 5, "guid": "request-context@1", "method": "fetch", "params": map[string]any{
+		"url":           "https://example.com/login",
+		"jsonData":      `{"token":"secret"}`,
+		"formData":      []any{map[string]any{"name": "user", "value": "ada"}},
+		"multipartData": []any{map[string]any{"name": "key", "file": map[string]any{"name": "key.pem", "buffer": "c2VjcmV0"}}},
+	}})
+
+	lines := readLogLines(t, &buf)
+	require.Len(t, lines, 4)
+	require.Equal(t, "DEBUG", lines[0]["level"])
+	require.Equal(t, "protocol", lines[0]["msg"])
+	require.Equal(t, "send", lines[0]["direction"])
+	require.Equal(t, "Frame", lines[0]["objectType"])
+	require.Equal(t, "fill", lines[0]["method"])
+	require.Equal(t, float64(3), lines[0]["id"])
+	require.Positive(t, lines[0]["size"])
+	require.Equal(t, map[string]any{"selector": "#password", "value": redactedValue}, lines[0]["params"])
+
+	require.Equal(t, "receive", lines[1]["direction"])
+	require.Equal(t, float64(42), lines[1]["size"])
+	require.Equal(t, map[string]any{
+		"headers":  []any{map[string]any{"name": "Authorization", "value": redactedValue}},
+		"postData": redactedValue,
+		"url":      "https://example.com",
+	}, lines[1]["params"])
+
+	require.Equal(t, "cookies", lines[2]["method"])
+	require.Contains(t, lines[2], "duration")
+	require.Equal(t, map[string]any{
+		"cookies": []any{map[string]any{"name": "session", "value": redactedValue, "httpOnly": redactedValue}},
+	}, lines[2]["result"])
+
+	require.Equal(t, map[string]any{
+		"url":           "https://example.com/login",
+		"jsonData":      redactedValue,
+		"formData":      []any{map[string]any{"name": "user", "value": redactedValue}},
+		"multipartData": []any{map[string]any{"name": "key", "file": map[string]any{"name": "key.pem", "buffer": redactedValue}}},
+	}, lines[3]["params"])
+}
+
+func TestProtocolLogFilter(t *testing.T) {
+	t.Setenv("DEBUGP", "")
+	var buf bytes.Buffer
+	l := newProtocolLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), &ProtocolLogOptions{
+		Filter:     func(objectType, method string) bool { return objectType == "Page" },
+		Redactions: []ProtocolRedaction{},
+	})
+	l.send("Frame", map[string]any{"id": 1, "guid": "frame@1", "method": "click"})
+	l.send("Page", map[string]any{"id": 2, "guid": "page@1", "method": "setExtraHTTPHeaders", "params": map[string]any{
+		"headers": []any{map[string]any{"name": "Authorization", "value": "Bearer token"}},
+	}})
+	lines := readLogLines(t, &buf)
+	require.Len(t, lines, 1)
+	require.Equal(t, "setExtraHTTPHeaders", lines[0]["method"])
+	// No redaction rules.
+	require.Equal(t, map[string]any{
+		"headers": []any{map[string]any{"name": "Authorization", "value": "Bearer token"}},
+	}, lines[0]["params"])
+
+	// Nothing is logged when debug level is disabled.
+	buf.Reset()
+	l = newProtocolLogger(slog.New(slog.NewJSONHandler(&buf, nil)), nil)
+	l.send("Page", map[string]any{"id": 3, "guid": "page@1", "method": "title"})
+	require.Zero(t, buf.Len())
+}
@@ -0,0 +1,224 @@
+package playwright
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Directions of a [ProtocolRecord].
+const (
+	ProtocolSend    = "send"
+	ProtocolReceive = "receive"
+)
+
+// ProtocolRecord is a line of a protocol recording (see
+// [NewRecordingTransport]).
+type ProtocolRecord struct {
+	Time time.Time `json:"time"`
+	// Direction is [ProtocolSend] for messages to the driver and
+	// [ProtocolReceive] for messages from it.
+	Direction string          `json:"direction"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// NewRecordingTransport wraps t to write every message sent and received
+// through it to w, one [ProtocolRecord] per line. See also
+// [RunOptions.RecordProtocol].
+func NewRecordingTransport(t Transport, w io.Writer) Transport {
+	return &recordingTransport{Transport: t, w: w}
+}
+
+type recordingTransport struct {
+	Transport
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *recordingTransport) Send(message []byte) error {
+	t.record(ProtocolSend, message)
+	return t.Transport.Send(message)
+}
+
+func (t *recordingTransport) Poll() ([]byte, error) {
+	message, err := t.Transport.Poll()
+	if err == nil {
+		t.record(ProtocolReceive, message)
+	}
+	return message, err
+}
+
+// record writes a record, ignoring failures: the recording must not break
+// the session it records.
+func (t *recordingTransport) record(direction string, message []byte) {
+	line, err := json.Marshal(ProtocolRecord{Time: time.Now(), Direction: direction, Message: message})
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(append(line, '\n'))
+}
+
+// NewReplayTransport returns a [Transport] that plays the driver's side of a
+// recording made with [NewRecordingTransport], so that [RunWithTransport]
+// runs automation code without a driver or browser.
+//
+// Each message sent is matched with the first unused recorded message that has
+// the same guid, method and params, and answered with its recorded reply, which
+// is found by id, so calls that were in flight concurrently can be replayed in
+// any order. The events received after the call in the recording, up to the
+// next recorded message sent, are played before the reply. Replies are
+// renumbered to the ids of the replayed calls. Sending a message that is not in
+// the recording fails.
+func NewReplayTransport(recording io.Reader) (Transport, error) {
+	t := &replayTransport{
+		ids:    map[int]int{},
+		closed: make(chan struct{}),
+	}
+	var current *replayCall
+	calls := map[int]*replayCall{} // by recorded id
+	scanner := bufio.NewScanner(recording)
+	scanner.Buffer(nil, 1<<30)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record ProtocolRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("could not read protocol recording line %d: %w", line, err)
+		}
+		msg := map[string]any{}
+		if err := json.Unmarshal(record.Message, &msg); err != nil {
+			return nil, fmt.Errorf("could not read protocol recording line %d: %w", line, err)
+		}
+		switch record.Direction {
+		case ProtocolSend:
+			key, err := replayKey(msg)
+			if err != nil {
+				return nil, fmt.Errorf("could not read protocol recording line %d: %w", line, err)
+			}
+			id, _ := msg["id"].(float64)
+			current = &replayCall{key: key, id: int(id)}
+			t.calls = append(t.calls, current)
+			calls[current.id] = current
+		case ProtocolReceive:
+			call := current
+			if id, ok := msg["id"].(float64); ok && calls[int(id)] != nil {
+				call = calls[int(id)]
+			}
+			if call == nil {
+				t.pending = append(t.pending, msg)
+			} else {
+				call.replies = append(call.replies, msg)
+			}
+		default:
+			return nil, fmt.Errorf("could not read protocol recording line %d: unknown direction %q", line, record.Direction)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read protocol recording: %w", err)
+	}
+	t.cond = sync.NewCond(&t.mu)
+	return t, nil
+}
+
+type replayCall struct {
+	key     string
+	id      int
+	replies []map[string]any
+	used    bool
+}
+
+type replayTransport struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	calls   []*replayCall
+	ids     map[int]int // recorded id -> id of the replayed call
+	pending []map[string]any
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// replayKey identifies a call by its guid, method and params. The metadata of
+// a call (e.g. its wall time) differs between runs and is not part of it.
+func replayKey(msg map[string]any) (string, error) {
+	params, err := json.Marshal(msg["params"])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v.%v(%s)", msg["guid"], msg["method"], params), nil
+}
+
+func (t *replayTransport) Send(message []byte) error {
+	msg := map[string]any{}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return fmt.Errorf("could not decode json: %w", err)
+	}
+	key, err := replayKey(msg)
+	if err != nil {
+		return err
+	}
+	id, _ := msg["id"].(float64)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, call := range t.calls {
+		if call.used || call.key != key {
+			continue
+		}
+		call.used = true
+		t.ids[call.id] = int(id)
+		t.pending = append(t.pending, call.replies...)
+		t.cond.Broadcast()
+		return nil
+	}
+	return fmt.Errorf("no recorded call matches %s", key)
+}
+
+func (t *replayTransport) Poll() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for len(t.pending) == 0 && !t.isClosed() {
+		t.cond.Wait()
+	}
+	if t.isClosed() {
+		return nil, errors.New("replay transport closed")
+	}
+	msg := t.pending[0]
+	t.pending = t.pending[1:]
+	if recordedID, ok := msg["id"].(float64); ok {
+		if id, ok := t.ids[int(recordedID)]; ok {
+			renumbered := make(map[string]any, len(msg))
+			for k, v := range msg {
+				renumbered[k] = v
+			}
+			renumbered["id"] = id
+			msg = renumbered
+		}
+	}
+	return json.Marshal(msg)
+}
+
+func (t *replayTransport) isClosed() bool {
+	select {
+	case <-t.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *replayTransport) Close() error {
+	t.once.Do(func() {
+		t.mu.Lock()
+		close(t.closed)
+		t.cond.Broadcast()
+		t.mu.Unlock()
+	})
+	return nil
+}
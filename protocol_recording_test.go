@@ -0,0 +1,102 @@
+package playwright
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplayProtocol(t *testing.T) {
+	clientReader, driverWriter := io.Pipe()
+	driverReader, clientWriter := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveFakeDriver(t, NewFramedTransport(driverReader, driverWriter))
+	}()
+	var recording bytes.Buffer
+	pw, err := RunWithTransport(NewRecordingTransport(NewFramedTransport(clientReader, clientWriter), &recording))
+	require.NoError(t, err)
+	require.NoError(t, pw.Stop())
+	<-done
+
+	var directions []string
+	scanner := bufio.NewScanner(bytes.NewReader(recording.Bytes()))
+	for scanner.Scan() {
+		var record ProtocolRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		require.False(t, record.Time.IsZero())
+		directions = append(directions, record.Direction)
+	}
+	require.Equal(t, []string{ProtocolSend, ProtocolReceive, ProtocolReceive, ProtocolReceive, ProtocolReceive, ProtocolReceive}, directions)
+
+	replay, err := NewReplayTransport(&recording)
+	require.NoError(t, err)
+	pw, err = RunWithTransport(replay)
+	require.NoError(t, err)
+	require.Equal(t, "webkit", pw.WebKit.Name())
+	require.NoError(t, pw.Stop())
+}
+
+func TestReplayTransportMatchesCalls(t *testing.T) {
+	recording := strings.Join([]string{
+		`{"time":"2024-01-01T00:00:00Z","direction":"send","message":{"id":7,"guid":"page","method":"title","params":{},"metadata":{"wallTime":1}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"receive","message":{"guid":"page","method":"console","params":{"text":"hi"}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"receive","message":{"id":7,"result":{"value":"first"}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"send","message":{"id":8,"guid":"page","method":"title","params":{}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"receive","message":{"id":8,"result":{"value":"second"}}}`,
+	}, "\n")
+	replay, err := NewReplayTransport(strings.NewReader(recording))
+	require.NoError(t, err)
+	defer replay.Close() //nolint:errcheck
+
+	poll := func() map[string]any {
+		data, err := replay.Poll()
+		require.NoError(t, err)
+		msg := map[string]any{}
+		require.NoError(t, json.Unmarshal(data, &msg))
+		return msg
+	}
+	require.NoError(t, replay.Send([]byte(`{"id":1,"guid":"page","method":"title","params":{},"metadata":{"wallTime":2}}`)))
+	require.Equal(t, "console", poll()["method"])
+	require.Equal(t, map[string]any{"id": float64(1), "result": map[string]any{"value": "first"}}, poll())
+	require.NoError(t, replay.Send([]byte(`{"id":2,"guid":"page","method":"title","params":{}}`)))
+	require.Equal(t, map[string]any{"id": float64(2), "result": map[string]any{"value": "second"}}, poll())
+
+	err = replay.Send([]byte(`{"id":3,"guid":"page","method":"title","params":{}}`))
+	require.ErrorContains(t, err, "no recorded call matches page.title({})")
+
+	require.NoError(t, replay.Close())
+	_, err = replay.Poll()
+	require.Error(t, err)
+}
+
+func TestReplayTransportMatchesRepliesByID(t *testing.T) {
+	// The reply to the first call was received after the second call was sent.
+	recording := strings.Join([]string{
+		`{"time":"2024-01-01T00:00:00Z","direction":"send","message":{"id":1,"guid":"page","method":"goto","params":{}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"send","message":{"id":2,"guid":"page","method":"title","params":{}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"receive","message":{"id":2,"result":{"value":"title"}}}`,
+		`{"time":"2024-01-01T00:00:00Z","direction":"receive","message":{"id":1,"result":{"value":"goto"}}}`,
+	}, "\n")
+	replay, err := NewReplayTransport(strings.NewReader(recording))
+	require.NoError(t, err)
+	defer replay.Close() //nolint:errcheck
+
+	poll := func() map[string]any {
+		data, err := replay.Poll()
+		require.NoError(t, err)
+		msg := map[string]any{}
+		require.NoError(t, json.Unmarshal(data, &msg))
+		return msg
+	}
+	require.NoError(t, replay.Send([]byte(`{"id":5,"guid":"page","method":"goto","params":{}}`)))
+	require.Equal(t, map[string]any{"id": float64(5), "result": map[string]any{"value": "goto"}}, poll())
+	require.NoError(t, replay.Send([]byte(`{"id":6,"guid":"page","method":"title","params":{}}`)))
+	require.Equal(t, map[string]any{"id": float64(6), "result": map[string]any{"value": "title"}}, poll())
+}
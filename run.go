@@ -13,9 +13,11 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 const (
@@ -41,6 +43,9 @@ var logger = slog.Default()
 type PlaywrightDriver struct {
 	Version string
 	options *RunOptions
+	bundle  *driverBundle // set while installing from RunOptions.DriverBundle
+	// findSystemNode is findSystemNode run at most once.
+	findSystemNode func() (systemNodeInfo, error)
 }
 
 func NewDriver(options ...*RunOptions) (*PlaywrightDriver, error) {
@@ -49,8 +54,9 @@ func NewDriver(options ...*RunOptions) (*PlaywrightDriver, error) {
 		return nil, err
 	}
 	return &PlaywrightDriver{
-		options: transformed,
-		Version: playwrightCliVersion,
+		options:        transformed,
+		Version:        playwrightCliVersion,
+		findSystemNode: sync.OnceValues(findSystemNode),
 	}, nil
 }
 
@@ -71,19 +77,29 @@ func getDefaultCacheDirectory() (string, error) {
 }
 
 func (d *PlaywrightDriver) isUpToDateDriver() (bool, error) {
-	if _, err := os.Stat(d.options.DriverDirectory); os.IsNotExist(err) {
-		if err := os.MkdirAll(d.options.DriverDirectory, 0o777); err != nil {
-			return false, fmt.Errorf("could not create driver directory: %w", err)
-		}
-	}
+	// This runs without the install lock, so it must not create the driver
+	// directory: it could end up in the way of a concurrent install moving its
+	// staging directory into place.
 	if _, err := os.Stat(getDriverCliJs(d.options.DriverDirectory)); os.IsNotExist(err) {
 		return false, nil
 	} else if err != nil {
 		return false, fmt.Errorf("could not check if driver is up2date: %w", err)
 	}
+	// A driver installed for a system Node.js lacks the downloaded one, which
+	// is needed again once the system Node.js is no longer used.
+	if nodePath := d.nodeExecutable(); nodePath == getNodeExecutable(d.options.DriverDirectory) && os.Getenv("PLAYWRIGHT_CLI_PATH") == "" {
+		if _, err := os.Stat(nodePath); os.IsNotExist(err) {
+			d.log("Node.js is missing from the driver", "path", nodePath)
+			return false, nil
+		}
+	}
 	cmd := d.Command("--version")
 	output, err := cmd.Output()
 	if err != nil {
+		if d.isPartiallyInstalledDriver() {
+			d.log("Repairing partially installed driver", "path", d.options.DriverDirectory)
+			return false, nil
+		}
 		return false, fmt.Errorf("could not run driver: %w", err)
 	}
 	if bytes.Contains(output, []byte(d.Version)) {
@@ -95,7 +111,7 @@ func (d *PlaywrightDriver) isUpToDateDriver() (bool, error) {
 
 // Command returns an exec.Cmd for the driver.
 func (d *PlaywrightDriver) Command(arg ...string) *exec.Cmd {
-	cmd := exec.Command(getNodeExecutable(d.options.DriverDirectory), append([]string{getDriverCliJs(d.options.DriverDirectory)}, arg...)...)
+	cmd := exec.Command(d.nodeExecutable(), append([]string{getDriverCliJs(d.options.DriverDirectory)}, arg...)...)
 	cmd.SysProcAttr = defaultSysProcAttr
 	return cmd
 }
@@ -143,9 +159,24 @@ func (d *PlaywrightDriver) Uninstall() error {
 //   - the matching per-platform Node.js binary from nodejs.org, placed at
 //     <DriverDirectory>/node[.exe].
 //
+// Installation is safe to run from several processes at once (e.g. parallel
+// `go test` packages each calling [Install]): it holds an advisory lock on
+// <DriverDirectory>.lock and assembles the driver in a staging directory that
+// is renamed into place once complete. A driver left half-installed by an
+// interrupted run is detected and reinstalled.
+//
+// Both downloads are verified before extraction: playwright-core against its
+// npm dist.integrity (SHA-512) and Node.js against SHASUMS256.txt. Pin the
+// expected digests with [RunOptions.PlaywrightCoreIntegrity] and
+// [RunOptions.NodeSHA256] when the registry or mirror itself is not trusted.
+//
 // When PLAYWRIGHT_NODEJS_PATH is set the Node.js download is skipped and the
 // preinstalled Node.js is used instead, which also covers platforms for which
-// nodejs.org has no prebuilt binary (e.g. linux/arm).
+// nodejs.org has no prebuilt binary (e.g. linux/arm). [RunOptions.UseSystemNode]
+// does the same with the node found on PATH, as long as it is compatible.
+//
+// When [RunOptions.DriverBundle] is set, both artifacts are read from that
+// bundle (see [PlaywrightDriver.BundleDriver]) and nothing is downloaded.
 func (d *PlaywrightDriver) DownloadDriver() error {
 	up2Date, err := d.isUpToDateDriver()
 	if err != nil {
@@ -155,31 +186,71 @@ func (d *PlaywrightDriver) DownloadDriver() error {
 		return d.patchDriverBundle()
 	}
 
-	d.log("Downloading driver", "path", d.options.DriverDirectory)
-
-	if err := d.downloadPlaywrightPackage(); err != nil {
+	unlock, err := d.lockDriverDirectory()
+	if err != nil {
 		return err
 	}
-	if err := d.downloadNode(); err != nil {
+	defer unlock()
+	// Another process may have installed the driver while we waited for the lock.
+	if up2Date, err = d.isUpToDateDriver(); err != nil {
 		return err
 	}
+	if up2Date {
+		return d.patchDriverBundle()
+	}
 
-	d.log("Downloaded driver successfully")
+	if d.options.DriverBundle != "" {
+		bundle, err := openDriverBundle(d.options.DriverBundle)
+		if err != nil {
+			return err
+		}
+		defer bundle.close()
+		if err := bundle.check(d.Version, d.usesDownloadedNode()); err != nil {
+			return err
+		}
+		d.bundle = bundle
+		defer func() { d.bundle = nil }()
+		d.log("Installing driver from bundle", "bundle", d.options.DriverBundle, "path", d.options.DriverDirectory)
+	} else {
+		d.log("Downloading driver", "path", d.options.DriverDirectory)
+	}
 
-	return d.patchDriverBundle()
+	if err := d.installDriver(); err != nil {
+		return err
+	}
+
+	d.log("Downloaded driver successfully")
+	return nil
 }
 
 // downloadPlaywrightPackage downloads the platform-independent playwright-core
 // package from the npm registry and extracts its "package/" contents into the
 // driver directory, so that <DriverDirectory>/package/cli.js exists.
+//
+// The tarball is verified against its npm integrity before anything is
+// extracted; a mismatch fails the download.
 func (d *PlaywrightDriver) downloadPlaywrightPackage() error {
-	url := fmt.Sprintf("%s/playwright-core/-/playwright-core-%s.tgz", npmRegistry(), d.Version)
-	body, err := downloadWithRetry(url)
+	integrity, err := d.playwrightPackageIntegrity()
+	if err != nil {
+		return err
+	}
+	archivePath, cleanup, err := d.fetch(playwrightPackageURL(d.Version), ProgressComponentPlaywrightCore)
 	if err != nil {
 		return fmt.Errorf("could not download playwright-core: %w", err)
 	}
+	defer cleanup()
+	if err := verifyFile(archivePath, func(r io.Reader) error { return verifyIntegrity(r, integrity) }); err != nil {
+		return fmt.Errorf("could not verify playwright-core: %w", err)
+	}
+	d.options.PlaywrightCoreIntegrity = integrity
+	d.log("Verified playwright-core", "integrity", integrity)
 
-	gzReader, err := gzip.NewReader(bytes.NewReader(body))
+	archive, err := d.openArchive(archivePath, ProgressComponentPlaywrightCore)
+	if err != nil {
+		return fmt.Errorf("could not read playwright-core archive: %w", err)
+	}
+	defer archive.Close() //nolint:errcheck
+	gzReader, err := gzip.NewReader(archive)
 	if err != nil {
 		return fmt.Errorf("could not read playwright-core archive: %w", err)
 	}
@@ -212,15 +283,19 @@ func (d *PlaywrightDriver) downloadPlaywrightPackage() error {
 	if !extracted {
 		return fmt.Errorf("no files extracted from playwright-core %s", d.Version)
 	}
+	archive.finish()
 	return nil
 }
 
 // downloadNode downloads the per-platform Node.js binary from nodejs.org and
 // places it at <DriverDirectory>/node[.exe]. It is a no-op when
-// PLAYWRIGHT_NODEJS_PATH is set, since a preinstalled Node.js is used then.
+// PLAYWRIGHT_NODEJS_PATH is set or RunOptions.UseSystemNode found a compatible
+// Node.js, since a preinstalled Node.js is used then.
+//
+// The archive is verified against its SHA-256 checksum before extraction; a
+// mismatch fails the download.
 func (d *PlaywrightDriver) downloadNode() error {
-	if os.Getenv("PLAYWRIGHT_NODEJS_PATH") != "" {
-		d.log("Skipping Node.js download, using PLAYWRIGHT_NODEJS_PATH")
+	if !d.usesDownloadedNode() {
 		return nil
 	}
 
@@ -229,30 +304,157 @@ func (d *PlaywrightDriver) downloadNode() error {
 		return err
 	}
 
-	archiveDir := fmt.Sprintf("node-v%s-%s", nodeVersion, suffix)
-	isWindows := runtime.GOOS == "windows"
-	ext := "tar.gz"
-	if isWindows {
-		ext = "zip"
+	archiveName, archiveDir := nodeArchive(suffix, runtime.GOOS)
+	checksum, err := d.nodeArchiveSHA256(archiveName)
+	if err != nil {
+		return err
 	}
-	url := fmt.Sprintf("%s/v%s/%s.%s", nodejsDistHost(), nodeVersion, archiveDir, ext)
 
-	body, err := downloadWithRetry(url)
+	archivePath, cleanup, err := d.fetch(nodeArchiveURL(archiveName), ProgressComponentNode)
 	if err != nil {
 		return fmt.Errorf("could not download Node.js: %w", err)
 	}
+	defer cleanup()
+	if err := verifyFile(archivePath, func(r io.Reader) error { return verifySHA256(r, checksum) }); err != nil {
+		return fmt.Errorf("could not verify Node.js: %w", err)
+	}
+	d.options.NodeSHA256 = checksum
+	d.log("Verified Node.js", "sha256", checksum)
 
+	archive, err := d.openArchive(archivePath, ProgressComponentNode)
+	if err != nil {
+		return fmt.Errorf("could not read archive: %w", err)
+	}
+	defer archive.Close() //nolint:errcheck
 	nodeDiskPath := getNodeExecutable(d.options.DriverDirectory)
-	if isWindows {
+	if runtime.GOOS == "windows" {
 		// The Windows archive is a zip with node.exe at "<archiveDir>/node.exe".
-		return extractZipEntry(body, archiveDir+"/node.exe", nodeDiskPath)
+		err = extractZipEntry(archivePath, archiveDir+"/node.exe", nodeDiskPath)
+	} else {
+		// Unix archives are gzipped tars with the binary at "<archiveDir>/bin/node".
+		err = extractTarGzEntry(archive, archiveDir+"/bin/node", nodeDiskPath)
+	}
+	if err != nil {
+		return err
+	}
+	archive.finish()
+	return nil
+}
+
+// fetch makes a driver artifact available as a local file: the file in the
+// offline bundle when RunOptions.DriverBundle is set, otherwise url downloaded
+// next to the driver directory. Downloads are streamed to disk rather than
+// held in memory. The returned func removes the download.
+func (d *PlaywrightDriver) fetch(url, component string) (string, func(), error) {
+	if d.bundle != nil {
+		return d.bundle.filePath(path.Base(url)), func() {}, nil
+	}
+	downloadDirectory := filepath.Dir(filepath.Clean(d.options.DriverDirectory))
+	if err := os.MkdirAll(downloadDirectory, 0o777); err != nil {
+		return "", nil, fmt.Errorf("could not create download directory: %w", err)
+	}
+	file, err := os.CreateTemp(downloadDirectory, "download-*-"+path.Base(url))
+	if err != nil {
+		return "", nil, fmt.Errorf("could not create download file: %w", err)
+	}
+	diskPath := file.Name()
+	file.Close() //nolint:errcheck
+	cleanup := func() { _ = os.Remove(diskPath) }
+	if err := d.downloadFile(url, diskPath, component); err != nil {
+		cleanup()
+		return "", nil, err
 	}
-	// Unix archives are gzipped tars with the binary at "<archiveDir>/bin/node".
-	return extractTarGzEntry(body, archiveDir+"/bin/node", nodeDiskPath)
+	return diskPath, cleanup, nil
+}
+
+// downloadFile downloads url to diskPath, reporting ProgressPhaseDownload
+// events for component.
+func (d *PlaywrightDriver) downloadFile(url, diskPath, component string) error {
+	err := d.downloadFileWithRetry(url, diskPath, func(done, total int64) {
+		d.progress(ProgressEvent{Component: component, Phase: ProgressPhaseDownload, BytesDone: done, BytesTotal: total})
+	})
+	if err != nil {
+		return err
+	}
+	if d.options.Progress != nil {
+		info, err := os.Stat(diskPath)
+		if err != nil {
+			return err
+		}
+		d.progress(ProgressEvent{Component: component, Phase: ProgressPhaseDownload, BytesDone: info.Size(), BytesTotal: info.Size(), Done: true})
+	}
+	return nil
+}
+
+// archiveFile is a driver archive opened for extraction, reporting the bytes
+// read as ProgressPhaseExtract events. finish reports the end of extraction.
+type archiveFile struct {
+	*progressReader
+	file   *os.File
+	finish func()
+}
+
+func (d *PlaywrightDriver) openArchive(diskPath, component string) (*archiveFile, error) {
+	file, err := os.Open(diskPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return nil, err
+	}
+	report := func(done, total int64) {
+		d.progress(ProgressEvent{Component: component, Phase: ProgressPhaseExtract, BytesDone: done, BytesTotal: total})
+	}
+	return &archiveFile{
+		progressReader: newProgressReader(file, info.Size(), report),
+		file:           file,
+		finish: func() {
+			d.progress(ProgressEvent{Component: component, Phase: ProgressPhaseExtract, BytesDone: info.Size(), BytesTotal: info.Size(), Done: true})
+		},
+	}, nil
+}
+
+func (a *archiveFile) Close() error {
+	return a.file.Close()
+}
+
+func playwrightPackageURL(version string) string {
+	return fmt.Sprintf("%s/playwright-core/-/playwright-core-%s.tgz", npmRegistry(), version)
+}
+
+func nodeArchiveURL(archiveName string) string {
+	return fmt.Sprintf("%s/v%s/%s", nodejsDistHost(), nodeVersion, archiveName)
+}
+
+// driverBundlePatches are the replacements patchDriverBundle applies to
+// playwright-core's lib/coreBundle.js.
+var driverBundlePatches = map[string]string{
+	"pageError.location.url":          `pageError.location?.url || ""`,
+	"pageError.location.lineNumber":   "pageError.location?.lineNumber || 0",
+	"pageError.location.columnNumber": "pageError.location?.columnNumber || 0",
+}
+
+func (d *PlaywrightDriver) coreBundlePath() string {
+	return filepath.Join(d.options.DriverDirectory, "package", "lib", "coreBundle.js")
+}
+
+// isPatchedDriverBundle reports whether coreBundle.js data has all of
+// driverBundlePatches applied.
+func isPatchedDriverBundle(data []byte) bool {
+	for _, patched := range driverBundlePatches {
+		if !bytes.Contains(data, []byte(patched)) {
+			return false
+		}
+	}
+	return true
 }
 
 func (d *PlaywrightDriver) patchDriverBundle() error {
-	coreBundlePath := filepath.Join(d.options.DriverDirectory, "package", "lib", "coreBundle.js")
+	d.progress(ProgressEvent{Component: ProgressComponentPlaywrightCore, Phase: ProgressPhasePatch})
+	defer d.progress(ProgressEvent{Component: ProgressComponentPlaywrightCore, Phase: ProgressPhasePatch, Done: true})
+	coreBundlePath := d.coreBundlePath()
 	data, err := os.ReadFile(coreBundlePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -261,13 +463,8 @@ func (d *PlaywrightDriver) patchDriverBundle() error {
 		return fmt.Errorf("could not read driver bundle: %w", err)
 	}
 
-	replacements := map[string]string{
-		"pageError.location.url":          `pageError.location?.url || ""`,
-		"pageError.location.lineNumber":   "pageError.location?.lineNumber || 0",
-		"pageError.location.columnNumber": "pageError.location?.columnNumber || 0",
-	}
 	changed := false
-	for original, patched := range replacements {
+	for original, patched := range driverBundlePatches {
 		originalBytes := []byte(original)
 		patchedBytes := []byte(patched)
 		if bytes.Contains(data, originalBytes) {
@@ -276,19 +473,18 @@ func (d *PlaywrightDriver) patchDriverBundle() error {
 		}
 	}
 	if !changed {
-		alreadyPatched := true
-		for _, patched := range replacements {
-			if !bytes.Contains(data, []byte(patched)) {
-				alreadyPatched = false
-				break
-			}
-		}
-		if alreadyPatched {
+		if isPatchedDriverBundle(data) {
 			return nil
 		}
 		return fmt.Errorf("could not patch driver bundle: pageError location pattern not found")
 	}
-	if err := os.WriteFile(coreBundlePath, data, 0o644); err != nil {
+	// Write via a temporary file so that a driver starting concurrently never
+	// loads a truncated bundle.
+	tmpPath := coreBundlePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("could not write patched driver bundle: %w", err)
+	}
+	if err := os.Rename(tmpPath, coreBundlePath); err != nil {
 		return fmt.Errorf("could not write patched driver bundle: %w", err)
 	}
 	return nil
@@ -306,6 +502,11 @@ func (d *PlaywrightDriver) run() (*connection, error) {
 		return nil, err
 	}
 	connection := newConnection(transport)
+	connection.protocolLog = newProtocolLogger(logger, d.options.ProtocolLog)
+	connection.instrumentation = d.options.Instrumentation
+	connection.onHandleLeak = d.options.OnHandleLeak
+	connection.asyncEvents = d.options.AsyncEvents
+	connection.nativeConnect = d.options.NativeConnect
 	return connection, nil
 }
 
@@ -334,6 +535,12 @@ func (d *PlaywrightDriver) installBrowsers() error {
 	cmd := d.Command(additionalArgs...)
 	cmd.Stdout = d.options.Stdout
 	cmd.Stderr = d.options.Stderr
+	if proxyEnv := d.browserDownloadProxyEnv(); proxyEnv != nil {
+		cmd.Env = append(os.Environ(), proxyEnv...)
+	}
+	if d.options.Progress != nil {
+		cmd.Stdout = &installProgressWriter{w: d.options.Stdout, report: d.options.Progress}
+	}
 	return cmd.Run()
 }
 
@@ -383,6 +590,82 @@ type RunOptions struct {
 	Logger   *slog.Logger
 	// DryRun does not install browser/dependencies. It will only print information.
 	DryRun bool
+	// PlaywrightCoreIntegrity pins the npm integrity ("sha512-<base64>") the
+	// downloaded playwright-core tarball must match. When empty, the
+	// dist.integrity published by the registry is used. After a successful
+	// download it holds the verified integrity.
+	PlaywrightCoreIntegrity string
+	// NodeSHA256 pins the hex SHA-256 the downloaded Node.js archive must match.
+	// When empty, the SHASUMS256.txt published next to the archive is used.
+	// After a successful download it holds the verified checksum.
+	NodeSHA256 string
+	// Progress, when set, is called with the progress of the driver download,
+	// extraction and patching, and of browser downloads (parsed from the output
+	// of the driver's install command). It is called synchronously from the
+	// installing goroutine, so it should return quickly.
+	Progress func(ProgressEvent)
+	// DriverBundle installs the driver from a bundle created by
+	// [PlaywrightDriver.BundleDriver] (a directory or a tar archive) instead of
+	// downloading it, for machines without internet access. The bundled
+	// archives are verified against the checksums recorded in the bundle, or
+	// against PlaywrightCoreIntegrity / NodeSHA256 when those are set.
+	DriverBundle string
+	// HTTPClient is used for the driver downloads (playwright-core from the npm
+	// registry and Node.js from its mirror) instead of http.DefaultClient, e.g.
+	// to go through an authenticated proxy or to trust a corporate CA.
+	//
+	// Browsers are downloaded by the driver's Node.js install command, which
+	// cannot use it. When its Transport is an *http.Transport with a Proxy, the
+	// proxy it picks for the browser download host is passed to the install
+	// command as HTTPS_PROXY/HTTP_PROXY. Custom CAs have to be passed with the
+	// NODE_EXTRA_CA_CERTS environment variable.
+	HTTPClient *http.Client
+	// UseSystemNode runs the driver with the node found on PATH instead of
+	// downloading Node.js, as long as its major version is supported by the
+	// pinned playwright-core; otherwise Node.js is downloaded and the reason
+	// logged. This suits environments where the downloaded (glibc) Node.js
+	// does not run, such as Alpine (musl) or Nix. PLAYWRIGHT_NODEJS_PATH takes
+	// precedence. It can also be enabled with PLAYWRIGHT_GO_USE_SYSTEM_NODE=1.
+	UseSystemNode bool
+	// DownloadHeaders are added to the driver download requests sent to the
+	// hosts of PLAYWRIGHT_GO_NPM_REGISTRY and NODE_MIRROR, e.g. an
+	// Authorization header for an internal registry or mirror. They are never
+	// sent to the default public hosts.
+	//
+	// Browsers are downloaded by the driver's Node.js install command, which
+	// has no way to add headers: a browser mirror at PLAYWRIGHT_DOWNLOAD_HOST
+	// that requires them cannot be used.
+	DownloadHeaders http.Header
+	// RecordProtocol receives every message exchanged with the driver as
+	// NDJSON, see [NewRecordingTransport]. Replay the recording with
+	// [NewReplayTransport].
+	RecordProtocol io.Writer
+	// ProtocolLog configures the protocol messages logged to Logger at debug
+	// level: which ones, and what is redacted. Connections made with
+	// BrowserType.Connect log like the instance they are made from.
+	ProtocolLog *ProtocolLogOptions
+	// Instrumentation is notified of every API call, including those on
+	// connections made with BrowserType.Connect.
+	Instrumentation Instrumentation
+	// OnHandleLeak is called when a page or browser context goes away while
+	// JSHandles or ElementHandles created in it, e.g. by EvaluateHandle, were
+	// never disposed with Dispose. Like Instrumentation, it is inherited by
+	// connections made with BrowserType.Connect. It runs on the goroutine that
+	// receives the driver's messages, so it must not block or call Playwright
+	// APIs. See also [Playwright.DebugObjects].
+	OnHandleLeak func(HandleLeak)
+	// AsyncEvents, when set, runs the handlers of the events of each object
+	// (Page, BrowserContext, Response...) in order on a goroutine of their
+	// own, so that slow handlers do not hold up the messages of the others.
+	// Without it, every handler runs on the goroutine that receives the
+	// driver's messages. Like Instrumentation, it is inherited by connections
+	// made with BrowserType.Connect.
+	AsyncEvents *AsyncEventOptions
+	// NativeConnect makes BrowserType.Connect dial the browser server's
+	// WebSocket itself instead of relaying it through the driver. The driver
+	// still provides the local utilities (HAR, tracing), but ExposeNetwork
+	// falls back to the driver relay. Without a driver, use [Connect].
+	NativeConnect bool
 }
 
 // Install does download the driver and the browsers.
@@ -424,11 +707,34 @@ func Run(options ...*RunOptions) (*Playwright, error) {
 	return playwright, err
 }
 
+// RunWithTransport starts a Playwright instance that talks to a driver over t,
+// e.g. the `run-driver` command of a driver running on another host or in a
+// container (see [NewFramedTransport]). The driver resolves file paths, such as
+// those of traces, HAR files and saved downloads, on its own filesystem.
+//
+// Of options, only Logger, ProtocolLog, Instrumentation, OnHandleLeak,
+// AsyncEvents and NativeConnect apply.
+func RunWithTransport(t Transport, options ...*RunOptions) (*Playwright, error) {
+	connection := newConnection(&customTransport{t})
+	if len(options) == 1 && options[0] != nil {
+		l := logger
+		if options[0].Logger != nil {
+			l = options[0].Logger
+		}
+		connection.protocolLog = newProtocolLogger(l, options[0].ProtocolLog)
+		connection.instrumentation = options[0].Instrumentation
+		connection.onHandleLeak = options[0].OnHandleLeak
+		connection.asyncEvents = options[0].AsyncEvents
+		connection.nativeConnect = options[0].NativeConnect
+	}
+	return connection.Start()
+}
+
 func transformRunOptions(options ...*RunOptions) (*RunOptions, error) {
 	option := &RunOptions{
 		Verbose: true,
 	}
-	if len(options) == 1 {
+	if len(options) == 1 && options[0] != nil {
 		option = options[0]
 	}
 	if option.OnlyInstallShell && option.NoInstallShell {
@@ -444,6 +750,9 @@ func transformRunOptions(options ...*RunOptions) (*RunOptions, error) {
 		}
 		option.DriverDirectory = filepath.Join(cacheDirectory, "ms-playwright-go", playwrightCliVersion)
 	}
+	if os.Getenv("PLAYWRIGHT_GO_USE_SYSTEM_NODE") == "1" {
+		option.UseSystemNode = true
+	}
 	if option.Stdout == nil {
 		option.Stdout = os.Stdout
 	}
@@ -501,8 +810,14 @@ func nodejsDistHost() string {
 // Platforms without a prebuilt Node.js binary (such as linux/arm, 32-bit ARM)
 // return an actionable error pointing at PLAYWRIGHT_NODEJS_PATH.
 func nodePlatformSuffix() (string, error) {
+	return nodePlatformSuffixFor(runtime.GOOS, runtime.GOARCH)
+}
+
+// nodePlatformSuffixFor is nodePlatformSuffix for an arbitrary GOOS/GOARCH,
+// e.g. when bundling a driver for another platform.
+func nodePlatformSuffixFor(goos, goarch string) (string, error) {
 	var os_ string
-	switch runtime.GOOS {
+	switch goos {
 	case "windows":
 		os_ = "win"
 	case "darwin":
@@ -510,11 +825,11 @@ func nodePlatformSuffix() (string, error) {
 	case "linux":
 		os_ = "linux"
 	default:
-		return "", unsupportedNodePlatformError()
+		return "", unsupportedNodePlatformError(goos, goarch)
 	}
 
 	var arch string
-	switch runtime.GOARCH {
+	switch goarch {
 	case "amd64":
 		arch = "x64"
 	case "arm64":
@@ -522,16 +837,26 @@ func nodePlatformSuffix() (string, error) {
 	default:
 		// Notably linux/arm (32-bit, e.g. Raspberry Pi armv7l): nodejs.org no
 		// longer ships a prebuilt binary, so we cannot download one.
-		return "", unsupportedNodePlatformError()
+		return "", unsupportedNodePlatformError(goos, goarch)
 	}
 
 	return fmt.Sprintf("%s-%s", os_, arch), nil
 }
 
-func unsupportedNodePlatformError() error {
+func unsupportedNodePlatformError(goos, goarch string) error {
 	return fmt.Errorf("no prebuilt Node.js %s is available for %s/%s; "+
 		"install Node.js yourself and set PLAYWRIGHT_NODEJS_PATH to its path",
-		nodeVersion, runtime.GOOS, runtime.GOARCH)
+		nodeVersion, goos, goarch)
+}
+
+// nodeArchive returns the name of the Node.js release archive for a platform
+// suffix and GOOS, along with the top-level directory inside it.
+func nodeArchive(suffix, goos string) (archiveName, archiveDir string) {
+	archiveDir = fmt.Sprintf("node-v%s-%s", nodeVersion, suffix)
+	if goos == "windows" {
+		return archiveDir + ".zip", archiveDir
+	}
+	return archiveDir + ".tar.gz", archiveDir
 }
 
 // safeJoin joins an archive entry name onto root, guarding against path
@@ -574,8 +899,8 @@ func writeFileFromReader(diskPath string, r io.Reader, mode os.FileMode) error {
 
 // extractTarGzEntry extracts a single named entry from a gzipped tar archive to
 // diskPath and marks it executable.
-func extractTarGzEntry(archive []byte, entryName, diskPath string) error {
-	gzReader, err := gzip.NewReader(bytes.NewReader(archive))
+func extractTarGzEntry(archive io.Reader, entryName, diskPath string) error {
+	gzReader, err := gzip.NewReader(archive)
 	if err != nil {
 		return fmt.Errorf("could not read archive: %w", err)
 	}
@@ -600,11 +925,12 @@ func extractTarGzEntry(archive []byte, entryName, diskPath string) error {
 }
 
 // extractZipEntry extracts a single named entry from a zip archive to diskPath.
-func extractZipEntry(archive []byte, entryName, diskPath string) error {
-	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+func extractZipEntry(archivePath, entryName, diskPath string) error {
+	zipReader, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return fmt.Errorf("could not read archive: %w", err)
 	}
+	defer zipReader.Close() //nolint:errcheck
 	for _, file := range zipReader.File {
 		if file.Name != entryName {
 			continue
@@ -630,39 +956,3 @@ func makeFileExecutable(path string) error {
 	}
 	return nil
 }
-
-// downloadWithRetry downloads url, retrying a few times on transient failures.
-// It does not retry client errors (4xx), which are not transient.
-func downloadWithRetry(url string) ([]byte, error) {
-	var lastErr error
-	for attempt := 1; attempt <= 3; attempt++ {
-		body, retryable, err := download(url)
-		if err == nil {
-			return body, nil
-		}
-		lastErr = err
-		if !retryable {
-			break
-		}
-	}
-	return nil, lastErr
-}
-
-// download fetches url. The returned bool reports whether a failure is worth
-// retrying (network errors and 5xx are; 4xx are not).
-func download(url string) ([]byte, bool, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, true, fmt.Errorf("could not download from %s: %w", url, err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-	if resp.StatusCode != http.StatusOK {
-		retryable := resp.StatusCode >= 500
-		return nil, retryable, fmt.Errorf("got non 200 status code: %d (%s) from %s", resp.StatusCode, resp.Status, url)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, true, fmt.Errorf("could not read response body: %w", err)
-	}
-	return body, false, nil
-}
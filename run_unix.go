@@ -2,9 +2,70 @@
 
 package playwright
 
-import "syscall"
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
 
 var defaultSysProcAttr = &syscall.SysProcAttr{}
 
 // for WritableStream.Copy
 const defaultCopyBufSize = 1024 * 1024
+
+// interruptProcess asks process to exit gracefully.
+func interruptProcess(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
+
+// killProcessTree kills process and its descendants. Descendants that lead a
+// process group of their own, like the browsers Playwright launches detached,
+// are killed with their group.
+func killProcessTree(process *os.Process) error {
+	// The descendants are listed first: once process is gone, they are
+	// reparented and can no longer be told apart.
+	descendants, listErr := processDescendants(process.Pid)
+	if err := process.Kill(); err != nil {
+		return err
+	}
+	for _, pid := range descendants {
+		if pgid, err := syscall.Getpgid(pid); err == nil && pgid == pid {
+			_ = syscall.Kill(-pid, syscall.SIGKILL)
+		} else {
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+		}
+	}
+	if listErr != nil {
+		return fmt.Errorf("killed process %d, but could not list its descendants: %w", process.Pid, listErr)
+	}
+	return nil
+}
+
+// processDescendants lists the processes descending from pid with ps.
+func processDescendants(pid int) ([]int, error) {
+	output, err := exec.Command("ps", "-A", "-o", "pid=", "-o", "ppid=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not run ps: %w", err)
+	}
+	children := map[int][]int{}
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		child, err1 := strconv.Atoi(fields[0])
+		parent, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			children[parent] = append(children[parent], child)
+		}
+	}
+	var descendants []int
+	for queue := children[pid]; len(queue) > 0; queue = queue[1:] {
+		descendants = append(descendants, queue[0])
+		queue = append(queue, children[queue[0]]...)
+	}
+	return descendants, nil
+}
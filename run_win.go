@@ -2,9 +2,29 @@
 
 package playwright
 
-import "syscall"
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
 
 var defaultSysProcAttr = &syscall.SysProcAttr{HideWindow: true}
 
 // for WritableStream.Copy
 const defaultCopyBufSize = 64 * 1024
+
+// interruptProcess asks process to exit gracefully. Windows has no signal for
+// this, so it is killed.
+func interruptProcess(process *os.Process) error {
+	return process.Kill()
+}
+
+// killProcessTree kills process and the processes it started, browsers
+// included.
+func killProcessTree(process *os.Process) error {
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(process.Pid)).Run(); err != nil {
+		return process.Kill()
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+package playwright
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// The environment variables of ToHaveScreenshot:
+//
+//   - PLAYWRIGHT_GO_SNAPSHOT_DIR is the directory of the baseline files.
+//     Defaults to "<file>-snapshots" next to the calling "<file>_test.go", or
+//     "testdata/snapshots" when not called from a test file.
+//   - PLAYWRIGHT_GO_UPDATE_SNAPSHOTS is "missing" (the default) to write the
+//     baselines that do not exist yet, "all" to also overwrite the baselines
+//     that do not match, or "none" to never write baselines.
+//   - PLAYWRIGHT_GO_TEST_RESULTS_DIR is the directory the expected, actual and
+//     diff images are written to when an assertion fails. Defaults to
+//     "test-results".
+const (
+	snapshotDirEnv     = "PLAYWRIGHT_GO_SNAPSHOT_DIR"
+	updateSnapshotsEnv = "PLAYWRIGHT_GO_UPDATE_SNAPSHOTS"
+	testResultsDirEnv  = "PLAYWRIGHT_GO_TEST_RESULTS_DIR"
+)
+
+// PageScreenshotAssertions is implemented by [PageAssertions].
+type PageScreenshotAssertions interface {
+	// This function will wait until two consecutive page screenshots yield the same result, and then compare the last
+	// screenshot with the expectation.
+	//
+	//  name: Snapshot name.
+	ToHaveScreenshot(name string, options ...PageAssertionsToHaveScreenshotOptions) error
+}
+
+// LocatorScreenshotAssertions is implemented by [LocatorAssertions].
+type LocatorScreenshotAssertions interface {
+	// This function will wait until two consecutive locator screenshots yield the same result, and then compare the last
+	// screenshot with the expectation.
+	//
+	//  name: Snapshot name.
+	ToHaveScreenshot(name string, options ...LocatorAssertionsToHaveScreenshotOptions) error
+}
+
+type PageAssertionsToHaveScreenshotOptions struct {
+	// When set to `"disabled"`, stops CSS animations, CSS transitions and Web Animations. Animations get different
+	// treatment depending on their duration:
+	//  - finite animations are fast-forwarded to completion, so they'll fire `transitionend` event.
+	//  - infinite animations are canceled to initial state, and then played over after the screenshot.
+	// Defaults to `"disabled"` that disables animations.
+	Animations *ScreenshotAnimations `json:"animations"`
+	// When set to `"hide"`, screenshot will hide text caret. When set to `"initial"`, text caret behavior will not be
+	// changed.  Defaults to `"hide"`.
+	Caret *ScreenshotCaret `json:"caret"`
+	// An object which specifies clipping of the resulting image.
+	Clip *Rect `json:"clip"`
+	// When true, takes a screenshot of the full scrollable page, instead of the currently visible viewport. Defaults to
+	// `false`.
+	FullPage *bool `json:"fullPage"`
+	// Specify locators that should be masked when the screenshot is taken. Masked elements will be overlaid with a pink
+	// box `#FF00FF` (customized by MaskColor) that completely covers its bounding box.
+	Mask []Locator `json:"mask"`
+	// Specify the color of the overlay box for masked elements, in
+	// [CSS color format]. Default color is pink `#FF00FF`.
+	//
+	// [CSS color format]: https://developer.mozilla.org/en-US/docs/Web/CSS/color_value
+	MaskColor *string `json:"maskColor"`
+	// An acceptable ratio of pixels that are different to the total amount of pixels, between `0` and `1`. Unset by
+	// default.
+	MaxDiffPixelRatio *float64 `json:"maxDiffPixelRatio"`
+	// An acceptable amount of pixels that could be different. Unset by default.
+	MaxDiffPixels *int `json:"maxDiffPixels"`
+	// Hides default white background and allows capturing screenshots with transparency. Not applicable to `jpeg` images.
+	// Defaults to `false`.
+	OmitBackground *bool `json:"omitBackground"`
+	// When set to `"css"`, screenshot will have a single pixel per each css pixel on the page. For high-dpi devices, this
+	// will keep screenshots small. Using `"device"` option will produce a single pixel per each device pixel, so
+	// screenshots of high-dpi devices will be twice as large or even larger.
+	// Defaults to `"css"`.
+	Scale *ScreenshotScale `json:"scale"`
+	// Text of the stylesheet to apply while making the screenshot. This is where you can hide dynamic elements, make
+	// elements invisible or change their properties to help you creating repeatable screenshots. This stylesheet pierces
+	// the Shadow DOM and applies to the inner frames.
+	Style *string `json:"style"`
+	// An acceptable perceived color difference in the [YIQ color space]
+	// between the same pixel in compared images, between zero (strict) and one (lax). Defaults to `0.2`.
+	//
+	// [YIQ color space]: https://en.wikipedia.org/wiki/YIQ
+	Threshold *float64 `json:"threshold"`
+	// Time to retry the assertion for in milliseconds. Defaults to `5000`.
+	Timeout *float64 `json:"timeout"`
+}
+
+type LocatorAssertionsToHaveScreenshotOptions struct {
+	// When set to `"disabled"`, stops CSS animations, CSS transitions and Web Animations. Animations get different
+	// treatment depending on their duration:
+	//  - finite animations are fast-forwarded to completion, so they'll fire `transitionend` event.
+	//  - infinite animations are canceled to initial state, and then played over after the screenshot.
+	// Defaults to `"disabled"` that disables animations.
+	Animations *ScreenshotAnimations `json:"animations"`
+	// When set to `"hide"`, screenshot will hide text caret. When set to `"initial"`, text caret behavior will not be
+	// changed.  Defaults to `"hide"`.
+	Caret *ScreenshotCaret `json:"caret"`
+	// Specify locators that should be masked when the screenshot is taken. Masked elements will be overlaid with a pink
+	// box `#FF00FF` (customized by MaskColor) that completely covers its bounding box.
+	Mask []Locator `json:"mask"`
+	// Specify the color of the overlay box for masked elements, in
+	// [CSS color format]. Default color is pink `#FF00FF`.
+	//
+	// [CSS color format]: https://developer.mozilla.org/en-US/docs/Web/CSS/color_value
+	MaskColor *string `json:"maskColor"`
+	// An acceptable ratio of pixels that are different to the total amount of pixels, between `0` and `1`. Unset by
+	// default.
+	MaxDiffPixelRatio *float64 `json:"maxDiffPixelRatio"`
+	// An acceptable amount of pixels that could be different. Unset by default.
+	MaxDiffPixels *int `json:"maxDiffPixels"`
+	// Hides default white background and allows capturing screenshots with transparency. Not applicable to `jpeg` images.
+	// Defaults to `false`.
+	OmitBackground *bool `json:"omitBackground"`
+	// When set to `"css"`, screenshot will have a single pixel per each css pixel on the page. For high-dpi devices, this
+	// will keep screenshots small. Using `"device"` option will produce a single pixel per each device pixel, so
+	// screenshots of high-dpi devices will be twice as large or even larger.
+	// Defaults to `"css"`.
+	Scale *ScreenshotScale `json:"scale"`
+	// Text of the stylesheet to apply while making the screenshot. This is where you can hide dynamic elements, make
+	// elements invisible or change their properties to help you creating repeatable screenshots. This stylesheet pierces
+	// the Shadow DOM and applies to the inner frames.
+	Style *string `json:"style"`
+	// An acceptable perceived color difference in the [YIQ color space]
+	// between the same pixel in compared images, between zero (strict) and one (lax). Defaults to `0.2`.
+	//
+	// [YIQ color space]: https://en.wikipedia.org/wiki/YIQ
+	Threshold *float64 `json:"threshold"`
+	// Time to retry the assertion for in milliseconds. Defaults to `5000`.
+	Timeout *float64 `json:"timeout"`
+}
+
+func (pa *pageAssertionsImpl) ToHaveScreenshot(name string, options ...PageAssertionsToHaveScreenshotOptions) error {
+	var option PageAssertionsToHaveScreenshotOptions
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.Timeout == nil {
+		option.Timeout = pa.defaultTimeout
+	}
+	return expectScreenshot(pa.actualPage.(*pageImpl).channel, name, pa.isNot, option, nil)
+}
+
+func (la *locatorAssertionsImpl) ToHaveScreenshot(name string, options ...LocatorAssertionsToHaveScreenshotOptions) error {
+	var option PageAssertionsToHaveScreenshotOptions
+	if len(options) == 1 {
+		option = PageAssertionsToHaveScreenshotOptions{
+			Animations:        options[0].Animations,
+			Caret:             options[0].Caret,
+			Mask:              options[0].Mask,
+			MaskColor:         options[0].MaskColor,
+			MaxDiffPixelRatio: options[0].MaxDiffPixelRatio,
+			MaxDiffPixels:     options[0].MaxDiffPixels,
+			OmitBackground:    options[0].OmitBackground,
+			Scale:             options[0].Scale,
+			Style:             options[0].Style,
+			Threshold:         options[0].Threshold,
+			Timeout:           options[0].Timeout,
+		}
+	}
+	if option.Timeout == nil {
+		option.Timeout = la.defaultTimeout
+	}
+	if err := la.actualLocator.Err(); err != nil {
+		return err
+	}
+	locator := la.actualLocator.(*locatorImpl)
+	return expectScreenshot(locator.frame.page.channel, name, la.isNot, option, map[string]any{
+		"locator": map[string]any{
+			"frame":    locator.frame.channel,
+			"selector": locator.selector,
+		},
+	})
+}
+
+type expectScreenshotResult struct {
+	actual       []byte
+	previous     []byte
+	diff         []byte
+	errorMessage string
+	timedOut     bool
+	log          []string
+}
+
+// expectScreenshot compares the screenshots taken by the page channel with the
+// baseline called name. The driver retries until two consecutive screenshots
+// are the same and that one matches the baseline, or the timeout elapses.
+func expectScreenshot(channel *channel, name string, isNot bool, options PageAssertionsToHaveScreenshotOptions, overrides map[string]any) error {
+	update := os.Getenv(updateSnapshotsEnv)
+	switch update {
+	case "":
+		update = "missing"
+	case "missing", "all", "none":
+	default:
+		return fmt.Errorf("invalid %s %q, expected \"missing\", \"all\" or \"none\"", updateSnapshotsEnv, update)
+	}
+	if filepath.Ext(name) == "" {
+		name += ".png"
+	}
+	snapshotPath := name
+	if !filepath.IsAbs(name) {
+		snapshotPath = filepath.Join(snapshotDir(), name)
+	}
+	expected, err := os.ReadFile(snapshotPath)
+	missing := errors.Is(err, os.ErrNotExist)
+	if err != nil && !missing {
+		return fmt.Errorf("could not read snapshot: %w", err)
+	}
+	if missing && isNot {
+		return fmt.Errorf("A snapshot doesn't exist at %s, matchers using Not are expected to fail.", snapshotPath)
+	}
+
+	if overrides == nil {
+		overrides = map[string]any{}
+	}
+	if options.Animations == nil {
+		options.Animations = ScreenshotAnimationsDisabled
+	}
+	if options.Caret == nil {
+		options.Caret = ScreenshotCaretHide
+	}
+	if options.Scale == nil {
+		options.Scale = ScreenshotScaleCss
+	}
+	if options.Mask != nil {
+		masks := make([]map[string]any, 0)
+		for _, m := range options.Mask {
+			if m.Err() != nil { // ErrLocatorNotSameFrame
+				return m.Err()
+			}
+			if l, ok := m.(*locatorImpl); ok {
+				masks = append(masks, map[string]any{
+					"selector": l.selector,
+					"frame":    l.frame.channel,
+				})
+			}
+		}
+		options.Mask = nil
+		overrides["mask"] = masks
+	}
+	overrides["isNot"] = isNot
+	if !missing {
+		overrides["expected"] = base64.StdEncoding.EncodeToString(expected)
+	}
+	result, err := sendExpectScreenshot(channel, options, overrides)
+	if err != nil {
+		return fmt.Errorf("could not compare screenshot: %w", err)
+	}
+
+	if missing && result.errorMessage == "" {
+		if update == "none" {
+			return screenshotError(name, fmt.Sprintf("A snapshot doesn't exist at %s.", snapshotPath), result, nil)
+		}
+		if err := writeSnapshotFile(snapshotPath, result.actual); err != nil {
+			return err
+		}
+		if update == "all" {
+			return nil
+		}
+		return fmt.Errorf("A snapshot doesn't exist at %s, writing actual.", snapshotPath)
+	}
+	if result.errorMessage == "" {
+		return nil
+	}
+	if update == "all" && !missing && !isNot && result.actual != nil {
+		return writeSnapshotFile(snapshotPath, result.actual)
+	}
+	return screenshotError(name, result.errorMessage, result, expected)
+}
+
+func sendExpectScreenshot(channel *channel, options PageAssertionsToHaveScreenshotOptions, overrides map[string]any) (*expectScreenshotResult, error) {
+	values, err := channel.SendReturnAsDict("expectScreenshot", options, overrides)
+	if err != nil {
+		// Like `expect`, a failed comparison may be reported as a server error
+		// carrying the result in its errorDetails.
+		var detailed *errorWithDetails
+		if !errors.As(err, &detailed) {
+			return nil, err
+		}
+		values = detailed.details
+		if _, ok := values["errorMessage"]; !ok {
+			values["errorMessage"] = detailed.err.Error()
+		}
+		if _, ok := values["log"]; !ok && len(detailed.log) > 0 {
+			log := make([]any, 0, len(detailed.log))
+			for _, line := range detailed.log {
+				log = append(log, line)
+			}
+			values["log"] = log
+		}
+	}
+
+	result := &expectScreenshotResult{}
+	result.errorMessage, _ = values["errorMessage"].(string)
+	result.timedOut, _ = values["timedOut"].(bool)
+	if log, ok := values["log"].([]any); ok {
+		for _, line := range log {
+			if s, ok := line.(string); ok {
+				result.log = append(result.log, s)
+			}
+		}
+	}
+	for key, image := range map[string]*[]byte{"actual": &result.actual, "previous": &result.previous, "diff": &result.diff} {
+		encoded, ok := values[key].(string)
+		if !ok {
+			continue
+		}
+		if *image, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return nil, fmt.Errorf("could not decode %s screenshot: %w", key, err)
+		}
+	}
+	return result, nil
+}
+
+// screenshotError writes the images of a failed comparison to the test
+// results directory and describes the failure.
+func screenshotError(name string, message string, result *expectScreenshotResult, expected []byte) error {
+	var b strings.Builder
+	b.WriteString("Screenshot comparison failed:\n\n  ")
+	b.WriteString(strings.ReplaceAll(message, "\n", "\n  "))
+	b.WriteString("\n")
+
+	dir := os.Getenv(testResultsDirEnv)
+	if dir == "" {
+		dir = "test-results"
+	}
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	if filepath.IsAbs(base) {
+		base = filepath.Base(base)
+	}
+	var errs []error
+	for _, artifact := range []struct {
+		label, suffix string
+		data          []byte
+	}{
+		{"Expected", "expected", expected},
+		{"Received", "actual", result.actual},
+		{"Previous", "previous", result.previous},
+		{"Diff", "diff", result.diff},
+	} {
+		if artifact.data == nil {
+			continue
+		}
+		path := filepath.Join(dir, base+"-"+artifact.suffix+".png")
+		if err := writeSnapshotFile(path, artifact.data); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", artifact.label, path)
+	}
+	if result.timedOut {
+		b.WriteString("\n\nTimed out while comparing screenshots.")
+	}
+	if len(result.log) > 0 {
+		b.WriteString("\n\nCall log:\n")
+		b.WriteString(strings.Join(result.log, "\n"))
+	}
+	return errors.Join(append([]error{errors.New(b.String())}, errs...)...)
+}
+
+// snapshotDir returns the directory of the baselines, see [snapshotDirEnv].
+func snapshotDir() string {
+	if dir := os.Getenv(snapshotDirEnv); dir != "" {
+		return dir
+	}
+	pc := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, "_test.go") {
+			return strings.TrimSuffix(frame.File, "_test.go") + "-snapshots"
+		}
+		if !more {
+			return filepath.Join("testdata", "snapshots")
+		}
+	}
+}
+
+func writeSnapshotFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return fmt.Errorf("could not create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("could not write snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package playwright
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newScreenshotConnection returns a page channel whose expectScreenshot calls
+// are answered by reply, and the params of the last call.
+func newScreenshotConnection(t *testing.T, reply func(params map[string]any) map[string]any) (*channel, func() map[string]any) {
+	var mu sync.Mutex
+	var params map[string]any
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		mu.Lock()
+		params = msg["params"].(map[string]any)
+		mu.Unlock()
+		t.deliver(&message{ID: int(msg["id"].(uint32)), Result: reply(params)})
+	})
+	c := newFakeConnection(t, transport)
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+	return page.channel, func() map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return params
+	}
+}
+
+func setSnapshotDirs(t *testing.T) (snapshots, results string) {
+	snapshots, results = t.TempDir(), t.TempDir()
+	t.Setenv(snapshotDirEnv, snapshots)
+	t.Setenv(testResultsDirEnv, results)
+	t.Setenv(updateSnapshotsEnv, "")
+	return snapshots, results
+}
+
+func encodeImage(data string) string {
+	return base64.StdEncoding.EncodeToString([]byte(data))
+}
+
+func TestToHaveScreenshotWritesMissingSnapshot(t *testing.T) {
+	snapshots, _ := setSnapshotDirs(t)
+	channel, lastParams := newScreenshotConnection(t, func(map[string]any) map[string]any {
+		return map[string]any{"actual": encodeImage("stable")}
+	})
+
+	err := expectScreenshot(channel, "home", false, PageAssertionsToHaveScreenshotOptions{Timeout: Float(1000)}, nil)
+	require.EqualError(t, err, "A snapshot doesn't exist at "+filepath.Join(snapshots, "home.png")+", writing actual.")
+	params := lastParams()
+	require.NotContains(t, params, "expected")
+	require.Equal(t, false, params["isNot"])
+	require.Equal(t, ScreenshotAnimationsDisabled, params["animations"])
+	require.Equal(t, ScreenshotCaretHide, params["caret"])
+	require.Equal(t, ScreenshotScaleCss, params["scale"])
+	require.Equal(t, Float(1000), params["timeout"])
+	data, err := os.ReadFile(filepath.Join(snapshots, "home.png"))
+	require.NoError(t, err)
+	require.Equal(t, "stable", string(data))
+
+	// The baseline now exists and is sent along.
+	require.NoError(t, expectScreenshot(channel, "home", false, PageAssertionsToHaveScreenshotOptions{
+		MaxDiffPixels: Int(10),
+		Threshold:     Float(0.3),
+	}, nil))
+	params = lastParams()
+	require.Equal(t, encodeImage("stable"), params["expected"])
+	require.Equal(t, Int(10), params["maxDiffPixels"])
+	require.Equal(t, Float(0.3), params["threshold"])
+}
+
+func TestToHaveScreenshotWritesFailureImages(t *testing.T) {
+	snapshots, results := setSnapshotDirs(t)
+	require.NoError(t, os.WriteFile(filepath.Join(snapshots, "home.png"), []byte("expected"), 0o644))
+	channel, _ := newScreenshotConnection(t, func(map[string]any) map[string]any {
+		return map[string]any{
+			"errorMessage": "42 pixels (ratio 0.01 of all image pixels) are different.",
+			"actual":       encodeImage("actual"),
+			"diff":         encodeImage("diff"),
+			"log":          []any{"taking page screenshot"},
+		}
+	})
+
+	err := expectScreenshot(channel, "home", false, PageAssertionsToHaveScreenshotOptions{}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "42 pixels (ratio 0.01 of all image pixels) are different.")
+	require.Contains(t, err.Error(), "Diff: "+filepath.Join(results, "home-diff.png"))
+	require.Contains(t, err.Error(), "Call log:\ntaking page screenshot")
+	for name, want := range map[string]string{"home-expected.png": "expected", "home-actual.png": "actual", "home-diff.png": "diff"} {
+		data, err := os.ReadFile(filepath.Join(results, name))
+		require.NoError(t, err)
+		require.Equal(t, want, string(data))
+	}
+
+	// Updating all snapshots replaces the baseline instead.
+	t.Setenv(updateSnapshotsEnv, "all")
+	require.NoError(t, expectScreenshot(channel, "home", false, PageAssertionsToHaveScreenshotOptions{}, nil))
+	data, err := os.ReadFile(filepath.Join(snapshots, "home.png"))
+	require.NoError(t, err)
+	require.Equal(t, "actual", string(data))
+}
+
+func TestToHaveScreenshotNotWithoutSnapshot(t *testing.T) {
+	snapshots, _ := setSnapshotDirs(t)
+	channel, _ := newScreenshotConnection(t, func(map[string]any) map[string]any {
+		return map[string]any{"actual": encodeImage("stable")}
+	})
+
+	err := expectScreenshot(channel, "home.png", true, PageAssertionsToHaveScreenshotOptions{}, nil)
+	require.ErrorContains(t, err, "matchers using Not are expected to fail")
+	_, err = os.Stat(filepath.Join(snapshots, "home.png"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	t.Setenv(updateSnapshotsEnv, "none")
+	err = expectScreenshot(channel, "home.png", false, PageAssertionsToHaveScreenshotOptions{}, nil)
+	require.ErrorContains(t, err, "A snapshot doesn't exist at "+filepath.Join(snapshots, "home.png")+".")
+	_, err = os.Stat(filepath.Join(snapshots, "home.png"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	t.Setenv(updateSnapshotsEnv, "sometimes")
+	require.ErrorContains(t, expectScreenshot(channel, "home.png", false, PageAssertionsToHaveScreenshotOptions{}, nil), "invalid PLAYWRIGHT_GO_UPDATE_SNAPSHOTS")
+}
@@ -20,7 +20,7 @@ func TestSelectorsAddContextIsIdempotent(t *testing.T) {
 	t.Cleanup(func() { testIdAttributeName = prevTestID })
 
 	s := newSelectorsImpl()
-	ctx := &browserContextImpl{channelOwner: channelOwner{guid: "context@idempotent"}}
+	ctx := &browserContextImpl{browserContextState: &browserContextState{channelOwner: channelOwner{guid: "context@idempotent"}}}
 
 	s.addContext(ctx)
 	first, ok := s.contexts.Load("context@idempotent")
@@ -29,7 +29,7 @@ func TestSelectorsAddContextIsIdempotent(t *testing.T) {
 
 	// Re-adding the same context must be a no-op and must not replace the stored
 	// value (LoadOrStore keeps the first).
-	other := &browserContextImpl{channelOwner: channelOwner{guid: "context@idempotent"}}
+	other := &browserContextImpl{browserContextState: &browserContextState{channelOwner: channelOwner{guid: "context@idempotent"}}}
 	s.addContext(other)
 	stored, ok := s.contexts.Load("context@idempotent")
 	require.True(t, ok)
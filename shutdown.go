@@ -0,0 +1,90 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StopWithTimeout stops the Playwright instance like [Playwright.Stop], but
+// gracefully first and within timeout:
+//
+//  1. It closes every browser context, which saves their HARs and videos, and
+//     then every browser.
+//  2. It closes the connection to the driver and waits for the driver to exit.
+//  3. If that did not happen by the deadline, it kills the driver and the
+//     processes it started, browsers included. On Unix, the browsers run in
+//     process groups of their own and are found with ps; if ps cannot be
+//     run, only the driver is killed and the error says so.
+//
+// The returned error joins the errors of closing contexts and browsers with
+// a description of what had to be killed, if anything. Browsers of
+// connections made with BrowserType.Connect are not closed.
+func (p *Playwright) StopWithTimeout(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	var errs []error
+	closed := make(chan error, 1)
+	go func() { closed <- p.closeBrowsers() }()
+	select {
+	case err := <-closed:
+		errs = append(errs, err)
+	case <-time.After(time.Until(deadline)):
+		errs = append(errs, fmt.Errorf("%w: browsers did not close within %s", ErrTimeout, timeout))
+	}
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- p.connection.Stop() }()
+	select {
+	case err := <-stopped:
+		return errors.Join(append(errs, err)...)
+	case <-time.After(time.Until(deadline)):
+	}
+
+	pt, ok := p.connection.transport.(*pipeTransport)
+	if !ok || pt.process == nil {
+		return errors.Join(append(errs, fmt.Errorf("%w: connection did not close within %s", ErrTimeout, timeout))...)
+	}
+	if err := killProcessTree(pt.process); err != nil {
+		errs = append(errs, fmt.Errorf("could not kill driver process %d: %w", pt.process.Pid, err))
+	}
+	errs = append(errs, fmt.Errorf("%w: driver did not exit within %s, killed driver process %d and its browsers", ErrTimeout, timeout, pt.process.Pid))
+	// Wait returns once the killed driver is reaped, which also runs the
+	// connection cleanup.
+	<-stopped
+	return errors.Join(errs...)
+}
+
+// closeBrowsers closes every browser context, then every browser of the
+// instance, each in the order they were created.
+func (p *Playwright) closeBrowsers() error {
+	var contexts []*browserContextImpl
+	var browsers []*browserImpl
+	p.connection.objectsMu.RLock()
+	p.connection.objects.Range(func(_ string, object *channelOwner) bool {
+		switch impl := object.channel.object.(type) {
+		case *browserContextImpl:
+			contexts = append(contexts, impl)
+		case *browserImpl:
+			browsers = append(browsers, impl)
+		}
+		return true
+	})
+	p.connection.objectsMu.RUnlock()
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].created < contexts[j].created })
+	sort.Slice(browsers, func(i, j int) bool { return browsers[i].created < browsers[j].created })
+
+	var errs []error
+	for _, context := range contexts {
+		if err := context.Close(); err != nil && !errors.Is(err, ErrTargetClosed) {
+			errs = append(errs, fmt.Errorf("could not close browser context %s: %w", context.guid, err))
+		}
+	}
+	for _, browser := range browsers {
+		if err := browser.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("could not close browser %s: %w", browser.guid, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+//go:build !windows
+
+package playwright
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopWithTimeout(t *testing.T) {
+	pw := newFakeDriverPlaywright(t, "cat >/dev/null\n")
+	require.NoError(t, pw.StopWithTimeout(5*time.Second))
+}
+
+func TestStopWithTimeoutKillsDriver(t *testing.T) {
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("setsid is not available")
+	}
+	pidFile := filepath.Join(t.TempDir(), "pids")
+	// The driver ignores its stdin closing and has two children: one in its
+	// process group, and one leading a process group of its own, like the
+	// browsers Playwright launches.
+	pw := newFakeDriverPlaywright(t, "sleep 60 &\necho $! > "+pidFile+
+		"\nsetsid sleep 60 &\necho $! >> "+pidFile+"\nexec sleep 60\n")
+	var childPIDs []int
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(pidFile)
+		lines := strings.Fields(string(data))
+		if err != nil || len(lines) != 2 || !strings.HasSuffix(string(data), "\n") {
+			return false
+		}
+		childPIDs = nil
+		for _, line := range lines {
+			pid, err := strconv.Atoi(line)
+			if err != nil {
+				return false
+			}
+			childPIDs = append(childPIDs, pid)
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	driverPID := pw.Pid()
+
+	start := time.Now()
+	err := pw.StopWithTimeout(200 * time.Millisecond)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorContains(t, err, "killed driver process "+strconv.Itoa(driverPID)+" and its browsers")
+	for _, pid := range childPIDs {
+		require.Eventually(t, func() bool { return !processRunning(pid) }, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestCloseBrowsersInCreationOrder(t *testing.T) {
+	var mu sync.Mutex
+	var closed []string
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		mu.Lock()
+		closed = append(closed, msg["guid"].(string))
+		mu.Unlock()
+		t.deliver(&message{ID: int(msg["id"].(uint32)), Result: map[string]any{}})
+	})
+	c := newFakeConnection(t, transport)
+	browserType := newBrowserType(&c.rootObject.channelOwner, "BrowserType", "browser-type@1", map[string]any{})
+	// Sorting the guids as strings would close browser@10 first.
+	for _, guid := range []string{"browser@2", "browser@10", "browser@3"} {
+		newBrowser(&browserType.channelOwner, "Browser", guid, map[string]any{})
+	}
+	pw := &Playwright{}
+	pw.connection = c
+	require.NoError(t, pw.closeBrowsers())
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"browser@2", "browser@10", "browser@3"}, closed)
+}
+
+// processRunning reports whether pid is alive and not a zombie.
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil || process.Signal(syscall.Signal(0)) != nil {
+		return false
+	}
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	return err != nil || !strings.Contains(string(stat), ") Z ")
+}
@@ -0,0 +1,290 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// PlaywrightSoftAssertions is implemented by [PlaywrightAssertions].
+type PlaywrightSoftAssertions interface {
+	// Creates a [SoftAssertions] object whose assertions record their failures instead of returning them, to report
+	// them together with [SoftAssertions.Err].
+	Soft() SoftAssertions
+}
+
+// SoftAssertions are assertions that record their failures instead of
+// returning them, so that one test reports every mismatch at once, see
+// [PlaywrightAssertions.Soft]:
+//
+//	soft := expect.Soft()
+//	soft.Locator(page.GetByLabel("Name")).ToHaveValue("Ada")
+//	soft.Locator(page.GetByLabel("Email")).ToHaveValue("ada@example.com")
+//	if err := soft.Err(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// The assertions of SoftAssertions always return nil.
+type SoftAssertions interface {
+	PlaywrightAssertions
+	// Err returns the failures recorded so far, each a [*SoftAssertionError],
+	// joined into one error, or nil if there are none.
+	Err() error
+}
+
+// SoftAssertionError is a failure recorded by [SoftAssertions].
+type SoftAssertionError struct {
+	// File and Line locate the failed assertion.
+	File string
+	Line int
+	// Err is the error of the assertion, with its call log.
+	Err error
+}
+
+func (e *SoftAssertionError) Error() string {
+	if e.File == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+func (e *SoftAssertionError) Unwrap() error {
+	return e.Err
+}
+
+type softAssertionsImpl struct {
+	assertions *playwrightAssertionsImpl
+	mu         sync.Mutex
+	errs       []error
+}
+
+func (pa *playwrightAssertionsImpl) Soft() SoftAssertions {
+	return &softAssertionsImpl{assertions: pa}
+}
+
+func (s *softAssertionsImpl) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+// record records err with the location of the assertion that returned it.
+func (s *softAssertionsImpl) record(err error) error {
+	if err == nil {
+		return nil
+	}
+	failure := &SoftAssertionError{Err: err}
+	if location, ok := serializeCallStack(false).metadata["location"].(map[string]any); ok {
+		failure.File, _ = location["file"].(string)
+		failure.Line, _ = location["line"].(int)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs = append(s.errs, failure)
+	return nil
+}
+
+func (s *softAssertionsImpl) Soft() SoftAssertions {
+	return s
+}
+
+func (s *softAssertionsImpl) APIResponse(response APIResponse) APIResponseAssertions {
+	return &softAPIResponseAssertions{soft: s, actual: s.assertions.APIResponse(response)}
+}
+
+func (s *softAssertionsImpl) Locator(locator Locator) LocatorAssertions {
+	return &softLocatorAssertions{soft: s, actual: s.assertions.Locator(locator)}
+}
+
+func (s *softAssertionsImpl) Page(page Page) PageAssertions {
+	return &softPageAssertions{soft: s, actual: s.assertions.Page(page)}
+}
+
+func (s *softAssertionsImpl) Poll(fn func() (any, error), options ...PlaywrightAssertionsPollOptions) PollAssertions {
+	return &softPollAssertions{soft: s, actual: s.assertions.Poll(fn, options...)}
+}
+
+func (s *softAssertionsImpl) ToPass(fn func() error, options ...PlaywrightAssertionsToPassOptions) error {
+	return s.record(s.assertions.ToPass(fn, options...))
+}
+
+type softAPIResponseAssertions struct {
+	soft   *softAssertionsImpl
+	actual APIResponseAssertions
+}
+
+func (s *softAPIResponseAssertions) Not() APIResponseAssertions {
+	return &softAPIResponseAssertions{soft: s.soft, actual: s.actual.Not()}
+}
+
+func (s *softAPIResponseAssertions) ToBeOK() error {
+	return s.soft.record(s.actual.ToBeOK())
+}
+
+type softLocatorAssertions struct {
+	soft   *softAssertionsImpl
+	actual LocatorAssertions
+}
+
+func (s *softLocatorAssertions) Not() LocatorAssertions {
+	return &softLocatorAssertions{soft: s.soft, actual: s.actual.Not()}
+}
+
+func (s *softLocatorAssertions) ToBeAttached(options ...LocatorAssertionsToBeAttachedOptions) error {
+	return s.soft.record(s.actual.ToBeAttached(options...))
+}
+
+func (s *softLocatorAssertions) ToBeChecked(options ...LocatorAssertionsToBeCheckedOptions) error {
+	return s.soft.record(s.actual.ToBeChecked(options...))
+}
+
+func (s *softLocatorAssertions) ToBeDisabled(options ...LocatorAssertionsToBeDisabledOptions) error {
+	return s.soft.record(s.actual.ToBeDisabled(options...))
+}
+
+func (s *softLocatorAssertions) ToBeEditable(options ...LocatorAssertionsToBeEditableOptions) error {
+	return s.soft.record(s.actual.ToBeEditable(options...))
+}
+
+func (s *softLocatorAssertions) ToBeEmpty(options ...LocatorAssertionsToBeEmptyOptions) error {
+	return s.soft.record(s.actual.ToBeEmpty(options...))
+}
+
+func (s *softLocatorAssertions) ToBeEnabled(options ...LocatorAssertionsToBeEnabledOptions) error {
+	return s.soft.record(s.actual.ToBeEnabled(options...))
+}
+
+func (s *softLocatorAssertions) ToBeFocused(options ...LocatorAssertionsToBeFocusedOptions) error {
+	return s.soft.record(s.actual.ToBeFocused(options...))
+}
+
+func (s *softLocatorAssertions) ToBeHidden(options ...LocatorAssertionsToBeHiddenOptions) error {
+	return s.soft.record(s.actual.ToBeHidden(options...))
+}
+
+func (s *softLocatorAssertions) ToBeInViewport(options ...LocatorAssertionsToBeInViewportOptions) error {
+	return s.soft.record(s.actual.ToBeInViewport(options...))
+}
+
+func (s *softLocatorAssertions) ToBeVisible(options ...LocatorAssertionsToBeVisibleOptions) error {
+	return s.soft.record(s.actual.ToBeVisible(options...))
+}
+
+func (s *softLocatorAssertions) ToContainClass(expected any, options ...LocatorAssertionsToContainClassOptions) error {
+	return s.soft.record(s.actual.ToContainClass(expected, options...))
+}
+
+func (s *softLocatorAssertions) ToContainText(expected any, options ...LocatorAssertionsToContainTextOptions) error {
+	return s.soft.record(s.actual.ToContainText(expected, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveAccessibleDescription(description any, options ...LocatorAssertionsToHaveAccessibleDescriptionOptions) error {
+	return s.soft.record(s.actual.ToHaveAccessibleDescription(description, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveAccessibleErrorMessage(errorMessage any, options ...LocatorAssertionsToHaveAccessibleErrorMessageOptions) error {
+	return s.soft.record(s.actual.ToHaveAccessibleErrorMessage(errorMessage, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveAccessibleName(name any, options ...LocatorAssertionsToHaveAccessibleNameOptions) error {
+	return s.soft.record(s.actual.ToHaveAccessibleName(name, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveAttribute(name string, value any, options ...LocatorAssertionsToHaveAttributeOptions) error {
+	return s.soft.record(s.actual.ToHaveAttribute(name, value, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveCSS(name string, value any, options ...LocatorAssertionsToHaveCSSOptions) error {
+	return s.soft.record(s.actual.ToHaveCSS(name, value, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveClass(expected any, options ...LocatorAssertionsToHaveClassOptions) error {
+	return s.soft.record(s.actual.ToHaveClass(expected, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveCount(count int, options ...LocatorAssertionsToHaveCountOptions) error {
+	return s.soft.record(s.actual.ToHaveCount(count, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveId(id any, options ...LocatorAssertionsToHaveIdOptions) error {
+	return s.soft.record(s.actual.ToHaveId(id, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveJSProperty(name string, value any, options ...LocatorAssertionsToHaveJSPropertyOptions) error {
+	return s.soft.record(s.actual.ToHaveJSProperty(name, value, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveRole(role AriaRole, options ...LocatorAssertionsToHaveRoleOptions) error {
+	return s.soft.record(s.actual.ToHaveRole(role, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveScreenshot(name string, options ...LocatorAssertionsToHaveScreenshotOptions) error {
+	return s.soft.record(s.actual.ToHaveScreenshot(name, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveText(expected any, options ...LocatorAssertionsToHaveTextOptions) error {
+	return s.soft.record(s.actual.ToHaveText(expected, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveValue(value any, options ...LocatorAssertionsToHaveValueOptions) error {
+	return s.soft.record(s.actual.ToHaveValue(value, options...))
+}
+
+func (s *softLocatorAssertions) ToHaveValues(values []any, options ...LocatorAssertionsToHaveValuesOptions) error {
+	return s.soft.record(s.actual.ToHaveValues(values, options...))
+}
+
+func (s *softLocatorAssertions) ToMatchAriaSnapshot(expected string, options ...LocatorAssertionsToMatchAriaSnapshotOptions) error {
+	return s.soft.record(s.actual.ToMatchAriaSnapshot(expected, options...))
+}
+
+type softPageAssertions struct {
+	soft   *softAssertionsImpl
+	actual PageAssertions
+}
+
+func (s *softPageAssertions) Not() PageAssertions {
+	return &softPageAssertions{soft: s.soft, actual: s.actual.Not()}
+}
+
+func (s *softPageAssertions) ToHaveScreenshot(name string, options ...PageAssertionsToHaveScreenshotOptions) error {
+	return s.soft.record(s.actual.ToHaveScreenshot(name, options...))
+}
+
+func (s *softPageAssertions) ToHaveTitle(titleOrRegExp any, options ...PageAssertionsToHaveTitleOptions) error {
+	return s.soft.record(s.actual.ToHaveTitle(titleOrRegExp, options...))
+}
+
+func (s *softPageAssertions) ToHaveURL(urlOrRegExp any, options ...PageAssertionsToHaveURLOptions) error {
+	return s.soft.record(s.actual.ToHaveURL(urlOrRegExp, options...))
+}
+
+func (s *softPageAssertions) ToMatchAriaSnapshot(expected string, options ...PageAssertionsToMatchAriaSnapshotOptions) error {
+	return s.soft.record(s.actual.ToMatchAriaSnapshot(expected, options...))
+}
+
+type softPollAssertions struct {
+	soft   *softAssertionsImpl
+	actual PollAssertions
+}
+
+func (s *softPollAssertions) Not() PollAssertions {
+	return &softPollAssertions{soft: s.soft, actual: s.actual.Not()}
+}
+
+func (s *softPollAssertions) ToContain(expected any) error {
+	return s.soft.record(s.actual.ToContain(expected))
+}
+
+func (s *softPollAssertions) ToEqual(expected any) error {
+	return s.soft.record(s.actual.ToEqual(expected))
+}
+
+func (s *softPollAssertions) ToMatch(pattern *regexp.Regexp) error {
+	return s.soft.record(s.actual.ToMatch(pattern))
+}
+
+func (s *softPollAssertions) ToSatisfy(description string, predicate func(value any) bool) error {
+	return s.soft.record(s.actual.ToSatisfy(description, predicate))
+}
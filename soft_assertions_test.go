@@ -0,0 +1,39 @@
+package playwright
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftAssertionsRecordFailures(t *testing.T) {
+	soft := NewPlaywrightAssertions(10).Soft()
+	value := func() (any, error) { return "Ada", nil }
+	boom := errors.New("boom")
+
+	require.NoError(t, soft.Poll(value).ToEqual("Ada"))
+	require.NoError(t, soft.Err())
+
+	_, _, line, _ := runtime.Caller(0)
+	require.NoError(t, soft.Poll(value).ToEqual("Grace"))
+	require.NoError(t, soft.Poll(value).Not().ToContain("Ad"))
+	require.NoError(t, soft.ToPass(func() error { return boom }))
+
+	err := soft.Err()
+	require.ErrorIs(t, err, boom)
+	var failures interface{ Unwrap() []error }
+	require.ErrorAs(t, err, &failures)
+	require.Len(t, failures.Unwrap(), 3)
+	for i, failure := range failures.Unwrap() {
+		var softErr *SoftAssertionError
+		require.ErrorAs(t, failure, &softErr)
+		require.Equal(t, "soft_assertions_test.go", softErr.File)
+		require.Equal(t, line+1+i, softErr.Line)
+	}
+	require.Contains(t, err.Error(), "soft_assertions_test.go:"+strconv.Itoa(line+1)+": Value expected to equal 'Grace'\nActual value: Ada")
+	require.Contains(t, err.Error(), "Value expected not to contain 'Ad'")
+	require.Contains(t, err.Error(), "ToPass failed after 1 attempt within 10ms: boom")
+}
@@ -2,6 +2,7 @@ package playwright
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"os"
 	"path/filepath"
@@ -11,7 +12,9 @@ type streamImpl struct {
 	channelOwner
 }
 
-func (s *streamImpl) SaveAs(path string) error {
+// SaveAs saves the stream to path with calls that are part of the API call of
+// ctx.
+func (s *streamImpl) SaveAs(ctx context.Context, path string) error {
 	err := os.MkdirAll(filepath.Dir(path), 0o777)
 	if err != nil {
 		return err
@@ -22,8 +25,9 @@ func (s *streamImpl) SaveAs(path string) error {
 	}
 	defer file.Close() //nolint:errcheck
 	writer := bufio.NewWriter(file)
+	channel := s.channel.within(ctx)
 	for {
-		binary, err := s.channel.Send("read", map[string]any{"size": 1024 * 1024})
+		binary, err := channel.Send("read", map[string]any{"size": 1024 * 1024})
 		if err != nil {
 			return err
 		}
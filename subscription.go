@@ -0,0 +1,100 @@
+package playwright
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Subscription is an event handler registration, returned by the On* methods
+// and [EventEmitter.On] and [EventEmitter.Once]. Unsubscribe removes the
+// handler; unlike [EventEmitter.RemoveListener], it removes exactly this
+// registration, even when the same func or closures of the same function are
+// registered several times.
+type Subscription interface {
+	// Unsubscribe removes the handler. Calling it again does nothing.
+	Unsubscribe()
+}
+
+type subscription struct {
+	once   sync.Once
+	remove func()
+}
+
+func (s *subscription) Unsubscribe() {
+	s.once.Do(s.remove)
+}
+
+// Subscriptions collects subscriptions that are removed together, e.g. the
+// handlers of a scraping job that runs on a long-lived page:
+//
+//	var subs playwright.Subscriptions
+//	defer subs.Unsubscribe()
+//	subs.Add(page.OnRequest(onRequest), page.OnResponse(onResponse))
+//
+// The zero value is ready to use.
+type Subscriptions struct {
+	mu   sync.Mutex
+	subs []Subscription
+}
+
+// Add adds subs to the collection.
+func (s *Subscriptions) Add(subs ...Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, subs...)
+}
+
+// Unsubscribe removes every subscription of the collection and empties it.
+func (s *Subscriptions) Unsubscribe() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = nil
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.Unsubscribe()
+	}
+}
+
+// subscriber is implemented by the emitters of playwright-go.
+type subscriber interface {
+	subscribe(name string, l listener) *subscription
+}
+
+// OnEvent registers handler for the event name of emitter, for example:
+//
+//	playwright.OnEvent(page, "request", func(request playwright.Request) {
+//		fmt.Println(request.URL())
+//	})
+//
+// Unlike [EventEmitter.On], the event payload is passed to handler without
+// reflection. An event without a payload, or with a nil one, is passed as the
+// zero T; an event whose payload is not a T is skipped with a warning.
+func OnEvent[T any](emitter EventEmitter, name string, handler func(T)) Subscription {
+	return subscribeEvent(emitter, name, handler, false)
+}
+
+// OnceEvent is like [OnEvent], but handler is removed after its first call.
+func OnceEvent[T any](emitter EventEmitter, name string, handler func(T)) Subscription {
+	return subscribeEvent(emitter, name, handler, true)
+}
+
+func subscribeEvent[T any](emitter EventEmitter, name string, handler func(T), once bool) Subscription {
+	s, ok := emitter.(subscriber)
+	if !ok {
+		if once {
+			return emitter.Once(name, handler)
+		}
+		return emitter.On(name, handler)
+	}
+	return s.subscribe(name, listener{handler: handler, call: func(payload ...any) {
+		var event T
+		if len(payload) > 0 && payload[0] != nil {
+			var ok bool
+			if event, ok = payload[0].(T); !ok {
+				logger.Warn("skipped an event whose payload is not of the handler's type", "event", name, "payload", fmt.Sprintf("%T", payload[0]), "handler", fmt.Sprintf("%T", handler))
+				return
+			}
+		}
+		handler(event)
+	}, once: once})
+}
@@ -0,0 +1,86 @@
+package playwright
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionUnsubscribe(t *testing.T) {
+	emitter := &eventEmitter{}
+	var calls []int
+	handler := func(n int) { calls = append(calls, n) }
+	first := emitter.On(testEventName, handler)
+	emitter.On(testEventName, handler)
+	require.Equal(t, 2, emitter.ListenerCount(testEventName))
+
+	// Only the first registration of the same func goes away.
+	first.Unsubscribe()
+	first.Unsubscribe()
+	require.Equal(t, 1, emitter.ListenerCount(testEventName))
+	emitter.Emit(testEventName, 1)
+	require.Equal(t, []int{1}, calls)
+
+	// Closures created in a loop.
+	var subs Subscriptions
+	for i := 0; i < 3; i++ {
+		subs.Add(emitter.On(testEventNameFoo, func() { calls = append(calls, i) }))
+	}
+	subs.Add(emitter.Once(testEventNameFoo, func() {}))
+	require.Equal(t, 4, emitter.ListenerCount(testEventNameFoo))
+	subs.Unsubscribe()
+	require.Zero(t, emitter.ListenerCount(testEventNameFoo))
+	subs.Unsubscribe()
+}
+
+func TestOnEvent(t *testing.T) {
+	emitter := &eventEmitter{}
+	var got []string
+	sub := OnEvent(emitter, testEventName, func(s string) { got = append(got, s) })
+	OnceEvent(emitter, testEventName, func(s string) { got = append(got, "once "+s) })
+	emitter.Emit(testEventName, "a")
+	// A payload of another type is skipped, a missing one is the zero value.
+	emitter.Emit(testEventName, 42)
+	emitter.Emit(testEventName)
+	sub.Unsubscribe()
+	emitter.Emit(testEventName, "c")
+	require.Equal(t, []string{"a", "once a", ""}, got)
+
+	// Emitters of other packages are supported through On.
+	other := &otherEmitter{EventEmitter: NewEventEmitter()}
+	OnEvent(other, testEventName, func(s string) { got = append(got, s) })
+	other.Emit(testEventName, "d")
+	require.Equal(t, "d", got[len(got)-1])
+}
+
+type otherEmitter struct {
+	EventEmitter
+}
+
+func TestSubscriptionUpdatesProtocolSubscription(t *testing.T) {
+	var mu sync.Mutex
+	var updates []map[string]any
+	transport := newFakeTransport(func(t *fakeTransport, msg map[string]any) {
+		if msg["method"] == "updateSubscription" {
+			mu.Lock()
+			updates = append(updates, msg["params"].(map[string]any))
+			mu.Unlock()
+		}
+	})
+	c := newFakeConnection(t, transport)
+	page := &channelOwner{}
+	page.createChannelOwner(page, &c.rootObject.channelOwner, "Page", "page@1", map[string]any{})
+	page.setEventSubscriptionMapping(map[string]string{"request": "request"})
+
+	first := OnEvent(page, "request", func(Request) {})
+	second := page.On("request", func(Request) {})
+	first.Unsubscribe()
+	second.Unsubscribe()
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []map[string]any{
+		{"event": "request", "enabled": true},
+		{"event": "request", "enabled": false},
+	}, updates)
+}
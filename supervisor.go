@@ -0,0 +1,163 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SupervisorOptions configures [Supervise].
+type SupervisorOptions struct {
+	// OnDisconnected is called with the cause when the driver goes away on its
+	// own, before it is restarted.
+	OnDisconnected func(error)
+	// OnRestart is called with the new instance once the driver was restarted,
+	// to re-create the browsers, contexts and pages that went away with the
+	// old one. When it fails, the new instance is stopped and the restart is
+	// retried.
+	OnRestart func(pw *Playwright) error
+	// MaxAttempts is the number of failed restart attempts in a row after which
+	// the supervisor gives up. Defaults to 5.
+	MaxAttempts int
+	// Backoff is the delay before the first restart attempt, doubled after each
+	// failed attempt up to 30 seconds. Defaults to 1 second.
+	Backoff time.Duration
+}
+
+// Supervisor keeps a Playwright driver running for long-lived processes,
+// restarting it when it exits unexpectedly. Get the current instance with
+// [Supervisor.Playwright]: after a restart, the previous instance and
+// everything created with it is closed.
+type Supervisor struct {
+	options SupervisorOptions
+	start   func() (*Playwright, error)
+
+	mu      sync.Mutex
+	pw      *Playwright
+	stopped bool
+	err     error
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// Supervise starts the driver like [Run] and restarts it whenever it
+// disconnects without [Supervisor.Stop] being called.
+func Supervise(runOptions *RunOptions, options SupervisorOptions) (*Supervisor, error) {
+	return newSupervisor(func() (*Playwright, error) { return Run(runOptions) }, options)
+}
+
+func newSupervisor(start func() (*Playwright, error), options SupervisorOptions) (*Supervisor, error) {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = 5
+	}
+	if options.Backoff <= 0 {
+		options.Backoff = time.Second
+	}
+	pw, err := start()
+	if err != nil {
+		return nil, err
+	}
+	s := &Supervisor{
+		options: options,
+		start:   start,
+		pw:      pw,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	s.watch(pw)
+	return s, nil
+}
+
+// Playwright returns the current Playwright instance.
+func (s *Supervisor) Playwright() *Playwright {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pw
+}
+
+// Done is closed when the supervisor stops: after [Supervisor.Stop], or when
+// it gave up restarting the driver (see [Supervisor.Err]).
+func (s *Supervisor) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns why the supervisor gave up restarting the driver, if it did.
+func (s *Supervisor) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Stop stops the current Playwright instance and the supervision.
+func (s *Supervisor) Stop() error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	close(s.stop)
+	pw, gaveUp := s.pw, s.err != nil
+	s.mu.Unlock()
+	if !gaveUp {
+		close(s.done)
+	}
+	return pw.Stop()
+}
+
+func (s *Supervisor) watch(pw *Playwright) {
+	pw.OnDisconnected(func(err error) {
+		if err != nil {
+			// The handler runs on the dispatch goroutine of the dead
+			// connection; do not hold it up while restarting.
+			go s.restart(err)
+		}
+	})
+}
+
+func (s *Supervisor) restart(cause error) {
+	if s.options.OnDisconnected != nil {
+		s.options.OnDisconnected(cause)
+	}
+	backoff := s.options.Backoff
+	lastErr := cause
+	for attempt := 0; attempt < s.options.MaxAttempts; attempt++ {
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+
+		pw, err := s.start()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if s.options.OnRestart != nil {
+			if err := s.options.OnRestart(pw); err != nil {
+				lastErr = fmt.Errorf("OnRestart failed: %w", err)
+				_ = pw.Stop()
+				continue
+			}
+		}
+		s.mu.Lock()
+		if s.stopped {
+			s.mu.Unlock()
+			_ = pw.Stop()
+			return
+		}
+		s.pw = pw
+		s.mu.Unlock()
+		s.watch(pw)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stopped {
+		s.err = errors.Join(fmt.Errorf("could not restart driver after %d attempts", s.options.MaxAttempts), lastErr)
+		close(s.done)
+	}
+}
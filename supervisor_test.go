@@ -0,0 +1,92 @@
+package playwright
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaywrightOnDisconnected(t *testing.T) {
+	transport := newFakeTransport(nil)
+	pw := newFakePlaywright(t, transport)
+	disconnected := make(chan error, 1)
+	pw.OnDisconnected(func(err error) { disconnected <- err })
+	require.NoError(t, transport.Close())
+	err := <-disconnected
+	require.ErrorIs(t, err, ErrTargetClosed)
+
+	// Handlers registered afterwards are called right away.
+	var lateErr error
+	pw.OnDisconnected(func(err error) { lateErr = err })
+	require.ErrorIs(t, lateErr, ErrTargetClosed)
+
+	// Stop is not a disconnection error.
+	pw = newFakePlaywright(t, newFakeTransport(nil))
+	pw.OnDisconnected(func(err error) { disconnected <- err })
+	require.NoError(t, pw.Stop())
+	require.NoError(t, <-disconnected)
+}
+
+func TestSupervisorRestartsDriver(t *testing.T) {
+	var transports []*fakeTransport
+	failNext := false
+	start := func() (*Playwright, error) {
+		if failNext {
+			failNext = false
+			return nil, errors.New("driver did not start")
+		}
+		transport := newFakeTransport(nil)
+		transports = append(transports, transport)
+		pw := newFakePlaywright(t, transport)
+		return pw, nil
+	}
+	restarted := make(chan *Playwright, 1)
+	causes := make(chan error, 1)
+	s, err := newSupervisor(start, SupervisorOptions{
+		Backoff:        time.Millisecond,
+		OnDisconnected: func(err error) { causes <- err },
+		OnRestart: func(pw *Playwright) error {
+			restarted <- pw
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	first := s.Playwright()
+
+	failNext = true
+	require.NoError(t, transports[0].Close())
+	require.ErrorIs(t, <-causes, ErrTargetClosed)
+	second := <-restarted
+	require.NotSame(t, first, second)
+	require.Eventually(t, func() bool { return s.Playwright() == second }, time.Second, time.Millisecond)
+	require.Len(t, transports, 2)
+
+	require.NoError(t, s.Stop())
+	<-s.Done()
+	require.NoError(t, s.Err())
+	// A requested stop does not restart the driver.
+	time.Sleep(10 * time.Millisecond)
+	require.Len(t, transports, 2)
+}
+
+func TestSupervisorGivesUp(t *testing.T) {
+	attempts := 0
+	var transport *fakeTransport
+	start := func() (*Playwright, error) {
+		attempts++
+		if attempts > 1 {
+			return nil, errors.New("driver did not start")
+		}
+		transport = newFakeTransport(nil)
+		return newFakePlaywright(t, transport), nil
+	}
+	s, err := newSupervisor(start, SupervisorOptions{Backoff: time.Millisecond, MaxAttempts: 2})
+	require.NoError(t, err)
+	require.NoError(t, transport.Close())
+	<-s.Done()
+	require.ErrorContains(t, s.Err(), "could not restart driver after 2 attempts")
+	require.ErrorContains(t, s.Err(), "driver did not start")
+	require.Equal(t, 3, attempts)
+}
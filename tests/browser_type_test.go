@@ -113,6 +113,54 @@ func TestBrowserTypeConnect(t *testing.T) {
 	require.NoError(t, browser1.Close())
 }
 
+func TestBrowserTypeLaunchServer(t *testing.T) {
+	BeforeEach(t)
+
+	server, err := browserType.LaunchServer()
+	require.NoError(t, err)
+	defer server.Kill() //nolint:errcheck
+	require.True(t, strings.HasPrefix(server.WSEndpoint(), "ws://"))
+
+	browser1, err := browserType.Connect(server.WSEndpoint())
+	require.NoError(t, err)
+	page, err := browser1.NewPage()
+	require.NoError(t, err)
+	result, err := page.Evaluate("11 * 11")
+	require.NoError(t, err)
+	require.Equal(t, result, 121)
+
+	disconnected := make(chan struct{})
+	browser1.OnDisconnected(func(playwright.Browser) { close(disconnected) })
+	require.NoError(t, server.Close())
+	<-disconnected
+	require.False(t, browser1.IsConnected())
+}
+
+func TestConnectWithoutDriver(t *testing.T) {
+	BeforeEach(t)
+
+	remoteServer, err := newRemoteServer()
+	require.NoError(t, err)
+	defer remoteServer.Close()
+
+	browser1, err := playwright.Connect(browserType.Name(), remoteServer.url)
+	require.NoError(t, err)
+	defer browser1.Close() //nolint:errcheck
+	require.Equal(t, browserType.Name(), browser1.BrowserType().Name())
+
+	page, err := browser1.NewPage()
+	require.NoError(t, err)
+	result, err := page.Evaluate("11 * 11")
+	require.NoError(t, err)
+	require.Equal(t, result, 121)
+
+	disconnected := make(chan struct{})
+	browser1.OnDisconnected(func(playwright.Browser) { close(disconnected) })
+	remoteServer.Close()
+	<-disconnected
+	require.False(t, browser1.IsConnected())
+}
+
 func TestBrowserTypeConnectShouldBeAbleToReconnectToBrowser(t *testing.T) {
 	BeforeEach(t)
 
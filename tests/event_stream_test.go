@@ -0,0 +1,61 @@
+package playwright_test
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/mxschmitt/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageEvents(t *testing.T) {
+	BeforeEach(t)
+
+	events := page.Events(gocontext.Background(), playwright.EventStreamOptions{
+		Events: []string{"request", "console"},
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => console.log("hello")`)
+	require.NoError(t, err)
+
+	request := (<-events).(playwright.RequestEvent)
+	require.Equal(t, server.EMPTY_PAGE, request.Request.URL())
+	console := (<-events).(playwright.ConsoleEvent)
+	require.Equal(t, "hello", console.Message.Text())
+
+	// The stream ends with the page.
+	require.NoError(t, page.Close())
+	for range events {
+	}
+}
+
+func TestBrowserContextEventsStopsWithContext(t *testing.T) {
+	BeforeEach(t)
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	events := context.Events(ctx)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, "request", (<-events).Name())
+	cancel()
+	for range events {
+	}
+	require.Zero(t, context.(playwright.EventEmitter).ListenerCount("request"))
+}
+
+func TestBrowserContextEventsRemovesClosedPages(t *testing.T) {
+	BeforeEach(t)
+
+	events := context.Events(gocontext.Background(), playwright.EventStreamOptions{
+		Events: []string{"worker"},
+	})
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.Equal(t, 1, newPage.(playwright.EventEmitter).ListenerCount("worker"))
+	require.NoError(t, newPage.Close())
+	require.Zero(t, newPage.(playwright.EventEmitter).ListenerCount("worker"))
+	require.NoError(t, context.Close())
+	for range events {
+	}
+}
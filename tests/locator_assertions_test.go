@@ -539,3 +539,26 @@ func TestLocatorToContainClass(t *testing.T) {
 	require.NoError(t, expect.Locator(locator).Not().ToContainClass([]string{"not-there", "hello", "baz"})) // Class not there
 	require.NoError(t, expect.Locator(locator).Not().ToContainClass([]string{"foo", "hello"}))              // Length mismatch
 }
+
+func TestLocatorAssertionsSoft(t *testing.T) {
+	BeforeEach(t)
+
+	require.NoError(t, page.SetContent(`<input id="name" value="Ada"><input id="email" value="ada@example.com">`))
+	soft := expect.Soft()
+	require.NoError(t, soft.Locator(page.Locator("#name")).ToHaveValue("Grace", playwright.LocatorAssertionsToHaveValueOptions{
+		Timeout: playwright.Float(500),
+	}))
+	require.NoError(t, soft.Locator(page.Locator("#email")).ToHaveValue("ada@example.com"))
+	require.NoError(t, soft.Locator(page.Locator("#email")).Not().ToBeVisible(playwright.LocatorAssertionsToBeVisibleOptions{
+		Timeout: playwright.Float(500),
+	}))
+
+	err := soft.Err()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "locator_assertions_test.go:")
+	require.Contains(t, err.Error(), "Grace")
+	require.Contains(t, err.Error(), "expected not to be visible")
+	var softErr *playwright.SoftAssertionError
+	require.ErrorAs(t, err, &softErr)
+	require.Equal(t, "locator_assertions_test.go", softErr.File)
+}
@@ -1,6 +1,7 @@
 package playwright_test
 
 import (
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -91,3 +92,32 @@ func TestPageAssertionsToHaveAccessibleErrorMessage(t *testing.T) {
 	}))
 	require.NoError(t, expect.Locator(locator).Not().ToHaveAccessibleErrorMessage("This should not be considered."))
 }
+
+func TestPageAssertionsToHaveScreenshot(t *testing.T) {
+	BeforeEach(t)
+	snapshots, results := t.TempDir(), t.TempDir()
+	t.Setenv("PLAYWRIGHT_GO_SNAPSHOT_DIR", snapshots)
+	t.Setenv("PLAYWRIGHT_GO_TEST_RESULTS_DIR", results)
+
+	require.NoError(t, page.SetContent(`<div id="box" style="width: 50px; height: 50px; background: red"></div>`))
+	err := expect.Page(page).ToHaveScreenshot("box")
+	require.ErrorContains(t, err, "writing actual")
+	require.FileExists(t, filepath.Join(snapshots, "box.png"))
+
+	require.NoError(t, expect.Page(page).ToHaveScreenshot("box"))
+	require.ErrorContains(t, expect.Locator(page.Locator("#box")).ToHaveScreenshot("box-element"), "writing actual")
+	require.NoError(t, expect.Locator(page.Locator("#box")).ToHaveScreenshot("box-element"))
+
+	_, err = page.Evaluate(`document.getElementById('box').style.background = 'blue'`)
+	require.NoError(t, err)
+	err = expect.Page(page).ToHaveScreenshot("box", playwright.PageAssertionsToHaveScreenshotOptions{
+		Timeout: playwright.Float(1000),
+	})
+	require.ErrorContains(t, err, "Screenshot comparison failed")
+	require.FileExists(t, filepath.Join(results, "box-diff.png"))
+	require.NoError(t, expect.Page(page).Not().ToHaveScreenshot("box"))
+	require.NoError(t, expect.Page(page).ToHaveScreenshot("box", playwright.PageAssertionsToHaveScreenshotOptions{
+		Mask:      []playwright.Locator{page.Locator("#box")},
+		MaskColor: playwright.String("red"),
+	}))
+}
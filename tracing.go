@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -17,6 +18,12 @@ type tracingImpl struct {
 }
 
 func (t *tracingImpl) Start(options ...TracingStartOptions) error {
+	return t.channel.instrumentAPICall(func(ctx context.Context) error {
+		return t.start(ctx, options...)
+	})
+}
+
+func (t *tracingImpl) start(ctx context.Context, options ...TracingStartOptions) error {
 	chunkOption := TracingStartChunkOptions{}
 	if len(options) == 1 {
 		if options[0].Sources != nil {
@@ -27,43 +34,57 @@ func (t *tracingImpl) Start(options ...TracingStartOptions) error {
 		chunkOption.Title = options[0].Title
 	}
 	innerStart := func() (any, error) {
-		if _, err := t.channel.Send("tracingStart", options); err != nil {
+		if _, err := t.channel.within(ctx).Send("tracingStart", options); err != nil {
 			return "", err
 		}
-		return t.channel.Send("tracingStartChunk", chunkOption)
+		return t.channel.within(ctx).Send("tracingStartChunk", chunkOption)
 	}
 	name, err := innerStart()
 	if err != nil {
 		return err
 	}
-	return t.startCollectingStacks(name.(string))
+	return t.startCollectingStacks(ctx, name.(string))
 }
 
 func (t *tracingImpl) StartChunk(options ...TracingStartChunkOptions) error {
-	name, err := t.channel.Send("tracingStartChunk", options)
-	if err != nil {
-		return err
-	}
-	return t.startCollectingStacks(name.(string))
+	return t.channel.instrumentAPICall(func(ctx context.Context) error {
+		name, err := t.channel.within(ctx).Send("tracingStartChunk", options)
+		if err != nil {
+			return err
+		}
+		return t.startCollectingStacks(ctx, name.(string))
+	})
 }
 
 func (t *tracingImpl) StopChunk(path ...string) error {
+	return t.channel.instrumentAPICall(func(ctx context.Context) error {
+		return t.stopChunk(ctx, path...)
+	})
+}
+
+func (t *tracingImpl) stopChunk(ctx context.Context, path ...string) error {
 	filePath := ""
 	if len(path) == 1 {
 		filePath = path[0]
 	}
-	return t.doStopChunk(filePath)
+	return t.doStopChunk(ctx, filePath)
 }
 
 func (t *tracingImpl) Stop(path ...string) error {
+	return t.channel.instrumentAPICall(func(ctx context.Context) error {
+		return t.stop(ctx, path...)
+	})
+}
+
+func (t *tracingImpl) stop(ctx context.Context, path ...string) error {
 	filePath := ""
 	if len(path) == 1 {
 		filePath = path[0]
 	}
-	if err := t.doStopChunk(filePath); err != nil {
+	if err := t.doStopChunk(ctx, filePath); err != nil {
 		return err
 	}
-	_, err := t.channel.Send("tracingStop")
+	_, err := t.channel.within(ctx).Send("tracingStop")
 	return err
 }
 
@@ -77,7 +98,7 @@ func (t *tracingImpl) resetStackCounter() {
 	}
 }
 
-func (t *tracingImpl) doStopChunk(filePath string) (err error) {
+func (t *tracingImpl) doStopChunk(ctx context.Context, filePath string) (err error) {
 	if t.isTracing {
 		t.isTracing = false
 		t.connection.setInTracing(false)
@@ -91,18 +112,18 @@ func (t *tracingImpl) doStopChunk(filePath string) (err error) {
 
 	if filePath == "" {
 		// Not interested in artifacts.
-		_, err = t.channel.Send("tracingStopChunk", map[string]any{
+		_, err = t.channel.within(ctx).Send("tracingStopChunk", map[string]any{
 			"mode": "discard",
 		})
 		if t.stacksId != "" {
-			return t.connection.LocalUtils().TraceDiscarded(t.stacksId)
+			return t.connection.LocalUtils().TraceDiscarded(ctx, t.stacksId)
 		}
 		return err
 	}
 
 	isLocal := !t.connection.isRemote
 	if isLocal {
-		result, err := t.channel.SendReturnAsDict("tracingStopChunk", map[string]any{
+		result, err := t.channel.within(ctx).SendReturnAsDict("tracingStopChunk", map[string]any{
 			"mode": "entries",
 		})
 		if err != nil {
@@ -112,7 +133,7 @@ func (t *tracingImpl) doStopChunk(filePath string) (err error) {
 		if !ok {
 			return fmt.Errorf("could not convert result to map: %v", result)
 		}
-		_, err = t.connection.LocalUtils().Zip(localUtilsZipOptions{
+		_, err = t.connection.LocalUtils().Zip(ctx, localUtilsZipOptions{
 			ZipFile:           filePath,
 			Entries:           entries.([]any),
 			StacksId:          t.stacksId,
@@ -123,7 +144,7 @@ func (t *tracingImpl) doStopChunk(filePath string) (err error) {
 		return err
 	}
 
-	result, err := t.channel.SendReturnAsDict("tracingStopChunk", map[string]any{
+	result, err := t.channel.within(ctx).SendReturnAsDict("tracingStopChunk", map[string]any{
 		"mode": "archive",
 	})
 	if err != nil {
@@ -138,17 +159,17 @@ func (t *tracingImpl) doStopChunk(filePath string) (err error) {
 	// The artifact may be missing if the browser closed while stopping tracing.
 	if artifact == nil {
 		if t.stacksId != "" {
-			return t.connection.LocalUtils().TraceDiscarded(t.stacksId)
+			return t.connection.LocalUtils().TraceDiscarded(ctx, t.stacksId)
 		}
 		return
 	}
-	if err := artifact.SaveAs(filePath); err != nil {
+	if err := artifact.saveAs(ctx, filePath); err != nil {
 		return err
 	}
-	if err := artifact.Delete(); err != nil {
+	if _, err := artifact.channel.within(ctx).Send("delete"); err != nil {
 		return err
 	}
-	_, err = t.connection.LocalUtils().Zip(localUtilsZipOptions{
+	_, err = t.connection.LocalUtils().Zip(ctx, localUtilsZipOptions{
 		ZipFile:           filePath,
 		Entries:           []any{},
 		StacksId:          t.stacksId,
@@ -159,12 +180,12 @@ func (t *tracingImpl) doStopChunk(filePath string) (err error) {
 	return err
 }
 
-func (t *tracingImpl) startCollectingStacks(name string) (err error) {
+func (t *tracingImpl) startCollectingStacks(ctx context.Context, name string) (err error) {
 	if !t.isTracing {
 		t.isTracing = true
 		t.connection.setInTracing(true)
 	}
-	t.stacksId, err = t.connection.LocalUtils().TracingStarted(name, t.isLive, t.tracesDir)
+	t.stacksId, err = t.connection.LocalUtils().TracingStarted(ctx, name, t.isLive, t.tracesDir)
 	return
 }
 
@@ -231,13 +252,14 @@ func (t *tracingImpl) StopHar() error {
 	if len(t.harRecorders) == 0 {
 		return fmt.Errorf("HAR recording has not been started")
 	}
-	return t.exportAllHars()
+	return t.channel.instrumentAPICall(t.exportAllHars)
 }
 
 // exportAllHars flushes every active HAR recording to disk. It is invoked by
 // StopHar and by APIRequestContext.Dispose so HARs started via the request
-// context are written even without an explicit StopHar call.
-func (t *tracingImpl) exportAllHars() error {
+// context are written even without an explicit StopHar call. Its calls are
+// part of the API call of ctx.
+func (t *tracingImpl) exportAllHars(ctx context.Context) error {
 	for harId, harMetaData := range t.harRecorders {
 		delete(t.harRecorders, harId)
 		overrides := map[string]any{}
@@ -247,7 +269,7 @@ func (t *tracingImpl) exportAllHars() error {
 		needCompressed := strings.HasSuffix(strings.ToLower(harMetaData.Path), ".zip")
 		if !t.connection.isRemote {
 			overrides["mode"] = "entries"
-			response, err := t.channel.SendReturnAsDict("harExport", overrides)
+			response, err := t.channel.within(ctx).SendReturnAsDict("harExport", overrides)
 			if err != nil {
 				return err
 			}
@@ -258,7 +280,7 @@ func (t *tracingImpl) exportAllHars() error {
 			if !ok {
 				return fmt.Errorf("could not convert HAR entries: %v", response)
 			}
-			if _, err = t.connection.LocalUtils().Zip(localUtilsZipOptions{
+			if _, err = t.connection.LocalUtils().Zip(ctx, localUtilsZipOptions{
 				ZipFile: harMetaData.Path,
 				Entries: entries,
 				Mode:    "write",
@@ -268,25 +290,25 @@ func (t *tracingImpl) exportAllHars() error {
 			continue
 		}
 		overrides["mode"] = "archive"
-		response, err := t.channel.SendReturnAsDict("harExport", overrides)
+		response, err := t.channel.within(ctx).SendReturnAsDict("harExport", overrides)
 		if err != nil {
 			return err
 		}
 		artifact := fromChannel(response["artifact"]).(*artifactImpl)
 		if needCompressed {
-			if err := artifact.SaveAs(harMetaData.Path); err != nil {
+			if err := artifact.saveAs(ctx, harMetaData.Path); err != nil {
 				return err
 			}
 		} else {
 			tmpPath := harMetaData.Path + ".tmp"
-			if err := artifact.SaveAs(tmpPath); err != nil {
+			if err := artifact.saveAs(ctx, tmpPath); err != nil {
 				return err
 			}
-			if err := t.connection.localUtils.HarUnzip(tmpPath, harMetaData.Path, harMetaData.ResourcesDir); err != nil {
+			if err := t.connection.localUtils.HarUnzip(ctx, tmpPath, harMetaData.Path, harMetaData.ResourcesDir); err != nil {
 				return err
 			}
 		}
-		if err := artifact.Delete(); err != nil {
+		if _, err := artifact.channel.within(ctx).Send("delete"); err != nil {
 			return err
 		}
 	}
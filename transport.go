@@ -2,10 +2,15 @@ package playwright
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"sync"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/json"
 )
@@ -16,19 +21,42 @@ type transport interface {
 	Close() error
 }
 
-type pipeTransport struct {
-	writer    io.WriteCloser
-	bufReader *bufio.Reader
-	closed    chan struct{}
-	onClose   func() error
-	process   *os.Process
+// Transport carries the messages of the Playwright protocol between the client
+// and a driver, each message being one JSON document. Implement it to talk to
+// a driver started elsewhere, e.g. `playwright run-driver` over SSH or in
+// another container, and pass it to [RunWithTransport]. [NewFramedTransport]
+// implements it for the byte streams of such a driver.
+type Transport interface {
+	// Send sends a message to the driver. It may be called concurrently.
+	Send(message []byte) error
+	// Poll blocks until the next message from the driver arrives. It must
+	// return an error once the transport is closed or the driver went away.
+	Poll() ([]byte, error)
+	// Close closes the transport.
+	Close() error
 }
 
-func (t *pipeTransport) Poll() (*message, error) {
-	if t.isClosed() {
-		return nil, fmt.Errorf("transport closed")
+// NewFramedTransport returns a [Transport] that exchanges messages with the
+// `run-driver` command of the driver through its standard output (r) and
+// standard input (w), framing each message with its length as a little-endian
+// uint32 like the driver does. Close closes w, which makes the driver exit,
+// and r if it is an [io.Closer].
+func NewFramedTransport(r io.Reader, w io.WriteCloser) Transport {
+	return &framedTransport{
+		reader:    r,
+		bufReader: bufio.NewReader(r),
+		writer:    w,
 	}
+}
 
+type framedTransport struct {
+	reader    io.Reader
+	bufReader *bufio.Reader
+	writeMu   sync.Mutex
+	writer    io.WriteCloser
+}
+
+func (t *framedTransport) Poll() ([]byte, error) {
 	var length uint32
 	err := binary.Read(t.bufReader, binary.LittleEndian, &length)
 	if err != nil {
@@ -40,17 +68,92 @@ func (t *pipeTransport) Poll() (*message, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not read protocol data: %w", err)
 	}
+	return data, nil
+}
+
+func (t *framedTransport) Send(message []byte) error {
+	lengthPadding := make([]byte, 4)
+	binary.LittleEndian.PutUint32(lengthPadding, uint32(len(message)))
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if _, err := t.writer.Write(append(lengthPadding, message...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *framedTransport) Close() error {
+	err := t.writer.Close()
+	if closer, ok := t.reader.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+	return err
+}
+
+// customTransport adapts a [Transport] of [RunWithTransport] to the messages of
+// the connection.
+type customTransport struct {
+	Transport
+}
+
+func (t *customTransport) Send(msg map[string]any) error {
+	data, err := encodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	return t.Transport.Send(data)
+}
 
+func (t *customTransport) Poll() (*message, error) {
+	data, err := t.Transport.Poll()
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(data)
+}
+
+// encodeMessage serializes a message to the driver.
+func encodeMessage(msg map[string]any) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal json: %w", err)
+	}
+	return data, nil
+}
+
+// decodeMessage parses a message from the driver.
+func decodeMessage(data []byte) (*message, error) {
 	msg := &message{}
 	if err := json.Unmarshal(data, &msg); err != nil {
 		return nil, fmt.Errorf("could not decode json: %w", err)
 	}
-	if os.Getenv("DEBUGP") != "" {
-		fmt.Fprintf(os.Stdout, "\x1b[33mRECV>\x1b[0m\n%s\n", data) //nolint:errcheck
-	}
+	msg.size = len(data)
 	return msg, nil
 }
 
+type pipeTransport struct {
+	framed  Transport
+	stdin   io.WriteCloser
+	closed  chan struct{}
+	onClose func() error
+	process *os.Process
+	wait    func() error // cmd.Wait, run once
+	stderr  *stderrTail
+	driver  *PlaywrightDriver
+}
+
+func (t *pipeTransport) Poll() (*message, error) {
+	if t.isClosed() {
+		return nil, fmt.Errorf("transport closed")
+	}
+
+	data, err := t.framed.Poll()
+	if err != nil {
+		return nil, t.readError(err)
+	}
+	return decodeMessage(data)
+}
+
 type message struct {
 	ID     int            `json:"id"`
 	GUID   string         `json:"guid"`
@@ -64,26 +167,19 @@ type message struct {
 	// ErrorDetails carries structured failure data (e.g. assertion `expect`
 	// failures in v1.61+: received value, timedOut, customErrorMessage).
 	ErrorDetails map[string]any `json:"errorDetails,omitempty"`
+	// size is the length of the encoded message, for the protocol log.
+	size int
 }
 
 func (t *pipeTransport) Send(msg map[string]any) error {
 	if t.isClosed() {
 		return fmt.Errorf("transport closed")
 	}
-	msgBytes, err := json.Marshal(msg)
+	data, err := encodeMessage(msg)
 	if err != nil {
-		return fmt.Errorf("pipeTransport: could not marshal json: %w", err)
-	}
-	if os.Getenv("DEBUGP") != "" {
-		fmt.Fprintf(os.Stdout, "\x1b[32mSEND>\x1b[0m\n%s\n", msgBytes) //nolint:errcheck
+		return fmt.Errorf("pipeTransport: %w", err)
 	}
-
-	lengthPadding := make([]byte, 4)
-	binary.LittleEndian.PutUint32(lengthPadding, uint32(len(msgBytes)))
-	if _, err = t.writer.Write(append(lengthPadding, msgBytes...)); err != nil {
-		return err
-	}
-	return nil
+	return t.framed.Send(data)
 }
 
 func (t *pipeTransport) Close() error {
@@ -95,6 +191,36 @@ func (t *pipeTransport) Close() error {
 	}
 }
 
+// driverExitTimeout bounds how long a failed read waits for the driver process
+// to exit before reporting the failure without its exit status.
+const driverExitTimeout = 5 * time.Second
+
+// readError turns a failure to read from the driver into a [DriverExitError],
+// unless the transport was closed on purpose.
+func (t *pipeTransport) readError(err error) error {
+	if t.isClosed() || t.wait == nil {
+		return err
+	}
+	exitErr := &DriverExitError{ExitCode: -1, Err: err}
+	exited := make(chan error, 1)
+	go func() { exited <- t.wait() }()
+	select {
+	case waitErr := <-exited:
+		var processErr *exec.ExitError
+		if waitErr == nil {
+			exitErr.ExitCode = 0
+		} else if errors.As(waitErr, &processErr) {
+			exitErr.ExitCode = processErr.ExitCode()
+			exitErr.State = processErr.String()
+		}
+	case <-time.After(driverExitTimeout):
+	}
+	if t.stderr != nil {
+		exitErr.Stderr = t.stderr.String()
+	}
+	return exitErr
+}
+
 func (t *pipeTransport) isClosed() bool {
 	select {
 	case <-t.closed:
@@ -107,10 +233,16 @@ func (t *pipeTransport) isClosed() bool {
 func newPipeTransport(driver *PlaywrightDriver, stderr io.Writer) (transport, error) {
 	t := &pipeTransport{
 		closed: make(chan struct{}, 1),
+		driver: driver,
 	}
 
 	cmd := driver.Command("run-driver")
-	cmd.Stderr = stderr
+	t.stderr = &stderrTail{w: stderr}
+	cmd.Stderr = t.stderr
+	// Do not let a browser that inherited the driver's stderr keep Wait from
+	// returning once the driver exited.
+	cmd.WaitDelay = time.Second
+	t.wait = sync.OnceValue(cmd.Wait)
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return nil, fmt.Errorf("could not create stdin pipe: %w", err)
@@ -119,8 +251,11 @@ func newPipeTransport(driver *PlaywrightDriver, stderr io.Writer) (transport, er
 	if err != nil {
 		return nil, fmt.Errorf("could not create stdout pipe: %w", err)
 	}
-	t.writer = stdin
-	t.bufReader = bufio.NewReader(stdout)
+	t.stdin = stdin
+	t.framed = NewFramedTransport(stdout, stdin)
+	if driver.options.RecordProtocol != nil {
+		t.framed = NewRecordingTransport(t.framed, driver.options.RecordProtocol)
+	}
 
 	t.onClose = func() error {
 		select {
@@ -128,11 +263,11 @@ func newPipeTransport(driver *PlaywrightDriver, stderr io.Writer) (transport, er
 		default:
 			close(t.closed)
 		}
-		if err := t.writer.Close(); err != nil {
+		if err := t.stdin.Close(); err != nil {
 			return err
 		}
 		// playwright-cli will exit when its stdin is closed
-		if err := cmd.Wait(); err != nil {
+		if err := t.wait(); err != nil {
 			return err
 		}
 		return nil
@@ -146,3 +281,37 @@ func newPipeTransport(driver *PlaywrightDriver, stderr io.Writer) (transport, er
 
 	return t, nil
 }
+
+// stderrTailSize is how much of the driver's stderr is kept for
+// [DriverExitError].
+const stderrTailSize = 4096
+
+// stderrTail forwards the driver's stderr to w and keeps its last lines.
+type stderrTail struct {
+	mu   sync.Mutex
+	w    io.Writer
+	tail []byte
+}
+
+func (s *stderrTail) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	s.tail = append(s.tail, p...)
+	if len(s.tail) > stderrTailSize {
+		s.tail = s.tail[len(s.tail)-stderrTailSize:]
+		// Drop the partial first line.
+		if i := bytes.IndexByte(s.tail, '\n'); i >= 0 {
+			s.tail = s.tail[i+1:]
+		}
+	}
+	s.mu.Unlock()
+	if s.w == nil {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
+
+func (s *stderrTail) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(bytes.TrimSpace(s.tail))
+}
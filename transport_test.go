@@ -0,0 +1,219 @@
+package playwright
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport is an in-memory transport. Sent messages are handed to
+// onSend, which may reply by calling deliver.
+type fakeTransport struct {
+	incoming chan *message
+	closed   chan struct{}
+	once     sync.Once
+	onSend   func(t *fakeTransport, msg map[string]any)
+}
+
+func newFakeTransport(onSend func(t *fakeTransport, msg map[string]any)) *fakeTransport {
+	return &fakeTransport{
+		incoming: make(chan *message, 16),
+		closed:   make(chan struct{}),
+		onSend:   onSend,
+	}
+}
+
+func (t *fakeTransport) Send(msg map[string]any) error {
+	if t.onSend != nil {
+		t.onSend(t, msg)
+	}
+	return nil
+}
+
+func (t *fakeTransport) Poll() (*message, error) {
+	select {
+	case msg := <-t.incoming:
+		return msg, nil
+	case <-t.closed:
+		return nil, errors.New("transport closed")
+	}
+}
+
+func (t *fakeTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+func (t *fakeTransport) deliver(msg *message) {
+	t.incoming <- msg
+}
+
+// newFakeConnection starts the receive loop of a connection over transport
+// without running the initialize handshake, and closes transport when the
+// test ends.
+func newFakeConnection(t testing.TB, transport transport) *connection {
+	t.Helper()
+	c := newConnection(transport)
+	go c.receiveLoop()
+	t.Cleanup(func() { _ = transport.Close() })
+	return c
+}
+
+// newFakePlaywright returns a Playwright instance over transport, like
+// newFakeConnection, without any browser types.
+func newFakePlaywright(t testing.TB, transport transport) *Playwright {
+	t.Helper()
+	c := newFakeConnection(t, transport)
+	c.onClose = transport.Close
+	pw := &Playwright{}
+	pw.connection = c
+	return pw
+}
+
+// newFakeDriver returns a driver whose node runs script, a shell script that
+// gets the arguments of the driver command after the cli.js path.
+func newFakeDriver(t *testing.T, script string) *PlaywrightDriver {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake driver is a shell script")
+	}
+	nodePath := filepath.Join(t.TempDir(), "node")
+	require.NoError(t, os.WriteFile(nodePath, []byte("#!/bin/sh\n"+script), 0o755))
+	t.Setenv("PLAYWRIGHT_NODEJS_PATH", nodePath)
+	driver, err := NewDriver(&RunOptions{DriverDirectory: t.TempDir()})
+	require.NoError(t, err)
+	return driver
+}
+
+// newFakeDriverPlaywright returns a Playwright instance whose driver runs
+// script (see newFakeDriver), without running the initialize handshake. The
+// driver is killed when the test ends.
+func newFakeDriverPlaywright(t *testing.T, script string) *Playwright {
+	t.Helper()
+	transport, err := newPipeTransport(newFakeDriver(t, script), io.Discard)
+	require.NoError(t, err)
+	pw := newFakePlaywright(t, transport)
+	// Registered last to run first: closing the transport waits for the
+	// driver to exit.
+	t.Cleanup(func() { _ = transport.(*pipeTransport).process.Kill() })
+	return pw
+}
+
+func TestPipeTransportReportsDriverExit(t *testing.T) {
+	driver := newFakeDriver(t, "echo 'Error: boom' >&2\nexit 3\n")
+
+	var stderr bytes.Buffer
+	transport, err := newPipeTransport(driver, &stderr)
+	require.NoError(t, err)
+	_, err = transport.Poll()
+	var exitErr *DriverExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 3, exitErr.ExitCode)
+	require.Equal(t, "Error: boom", exitErr.Stderr)
+	require.ErrorIs(t, err, io.EOF)
+	require.Contains(t, err.Error(), "exit status 3")
+	// stderr is still forwarded.
+	require.Equal(t, "Error: boom\n", stderr.String())
+}
+
+func TestStderrTailKeepsLastLines(t *testing.T) {
+	tail := &stderrTail{}
+	for i := 0; i < 1000; i++ {
+		_, _ = fmt.Fprintf(tail, "line %d\n", i)
+	}
+	lines := strings.Split(tail.String(), "\n")
+	require.Equal(t, "line 999", lines[len(lines)-1])
+	require.True(t, strings.HasPrefix(lines[0], "line "), lines[0])
+	require.LessOrEqual(t, len(tail.String()), stderrTailSize)
+}
+
+func TestFramedTransport(t *testing.T) {
+	var buf bytes.Buffer
+	sender := NewFramedTransport(strings.NewReader(""), nopWriteCloser{&buf})
+	require.NoError(t, sender.Send([]byte(`{"id":1}`)))
+	require.NoError(t, sender.Send([]byte(`{"id":2,"method":"x"}`)))
+	require.Equal(t, []byte{8, 0, 0, 0}, buf.Bytes()[:4])
+
+	receiver := NewFramedTransport(&buf, nopWriteCloser{io.Discard})
+	data, err := receiver.Poll()
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1}`, string(data))
+	data, err = receiver.Poll()
+	require.NoError(t, err)
+	require.Equal(t, `{"id":2,"method":"x"}`, string(data))
+	_, err = receiver.Poll()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// serveFakeDriver answers the initialize handshake of a client on the other
+// end of driver, then reads until the client goes away.
+func serveFakeDriver(t *testing.T, driver Transport) {
+	send := func(msg map[string]any) {
+		data, err := json.Marshal(msg)
+		require.NoError(t, err)
+		require.NoError(t, driver.Send(data))
+	}
+	data, err := driver.Poll()
+	require.NoError(t, err)
+	var initialize map[string]any
+	require.NoError(t, json.Unmarshal(data, &initialize))
+	require.Equal(t, "initialize", initialize["method"])
+
+	initializer := map[string]any{}
+	for _, name := range []string{"chromium", "firefox", "webkit"} {
+		guid := "browser-type@" + name
+		send(map[string]any{"guid": "", "method": "__create__", "params": map[string]any{
+			"type": "BrowserType", "guid": guid,
+			"initializer": map[string]any{"name": name, "executablePath": "/" + name},
+		}})
+		initializer[name] = map[string]any{"guid": guid}
+	}
+	send(map[string]any{"guid": "", "method": "__create__", "params": map[string]any{
+		"type": "Playwright", "guid": "playwright", "initializer": initializer,
+	}})
+	send(map[string]any{"id": initialize["id"], "result": map[string]any{
+		"playwright": map[string]any{"guid": "playwright"},
+	}})
+	for {
+		if _, err := driver.Poll(); err != nil {
+			return
+		}
+	}
+}
+
+func TestRunWithTransport(t *testing.T) {
+	clientReader, driverWriter := io.Pipe()
+	driverReader, clientWriter := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveFakeDriver(t, NewFramedTransport(driverReader, driverWriter))
+	}()
+
+	// A nil entry stands for the default options.
+	pw, err := RunWithTransport(NewFramedTransport(clientReader, clientWriter), nil)
+	require.NoError(t, err)
+	require.Equal(t, "firefox", pw.Firefox.Name())
+	require.Equal(t, "/webkit", pw.WebKit.ExecutablePath())
+	require.Zero(t, pw.Pid())
+
+	_, err = pw.Chromium.LaunchServer()
+	require.ErrorContains(t, err, "LaunchServer needs a Playwright instance started with Run")
+
+	require.NoError(t, pw.Stop())
+	<-done
+}
@@ -91,7 +91,7 @@ func newVideo(page *pageImpl) *videoImpl {
 	if page.isClosed {
 		video.pageClosed(page)
 	} else {
-		page.OnClose(video.pageClosed)
+		subscribeInternal(page, "close", video.pageClosed, false)
 	}
 	return video
 }
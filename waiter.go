@@ -17,6 +17,7 @@ type (
 		listeners []eventListener
 		errChan   chan error
 		waitFunc  func() (any, error)
+		channel   *channel
 	}
 	eventListener struct {
 		emitter EventEmitter
@@ -46,7 +47,7 @@ func (w *waiter) RejectOnEvent(emitter EventEmitter, event string, err error, pr
 			}
 		}
 	}
-	emitter.On(event, handler)
+	subscribeInternal(emitter, event, handler, false)
 	w.listeners = append(w.listeners, eventListener{
 		emitter: emitter,
 		event:   event,
@@ -92,7 +93,7 @@ func (w *waiter) WaitForEvent(emitter EventEmitter, event string, predicate any)
 		}()
 	}
 
-	emitter.On(event, handler)
+	subscribeInternal(emitter, event, handler, false)
 	w.listeners = append(w.listeners, eventListener{
 		emitter: emitter,
 		event:   event,
@@ -125,25 +126,42 @@ func (w *waiter) WaitForEvent(emitter EventEmitter, event string, predicate any)
 	return w
 }
 
+// WithChannel makes waiting an API call on the channel's object for the
+// Instrumentation.
+func (w *waiter) WithChannel(channel *channel) *waiter {
+	w.channel = channel
+	return w
+}
+
 // Wait waits for the waiter to return. It needs to call WaitForEvent once first.
 func (w *waiter) Wait() (any, error) {
-	if w.waitFunc == nil {
-		return nil, fmt.Errorf("waiter: call WaitForEvent first")
-	}
-	return w.waitFunc()
+	return w.RunAndWait(nil)
 }
 
-// RunAndWait waits for the waiter to return after calls func.
+// RunAndWait waits for the waiter to return after calls func, as a single API
+// call that spans func. The calls made by func are API calls of their own:
+// func is user code, which calls the API without the context of the call.
 func (w *waiter) RunAndWait(cb func() error) (any, error) {
 	if w.waitFunc == nil {
 		return nil, fmt.Errorf("waiter: call WaitForEvent first")
 	}
-	if cb != nil {
-		if err := cb(); err != nil {
-			w.errChan <- err
+	run := func() (any, error) {
+		if cb != nil {
+			if err := cb(); err != nil {
+				w.errChan <- err
+			}
 		}
+		return w.waitFunc()
 	}
-	return w.waitFunc()
+	if w.channel == nil {
+		return run()
+	}
+	var result any
+	err := w.channel.instrumentAPICall(func(context.Context) (err error) {
+		result, err = run()
+		return err
+	})
+	return result, err
 }
 
 func (w *waiter) createHandler(evChan chan<- any, predicate any) func(...any) {
@@ -98,7 +98,7 @@ func (ws *webSocketImpl) expectEvent(event string, cb func() error, options ...W
 			predicate = options[0].Predicate
 		}
 	}
-	waiter := newWaiter().WithTimeout(timeout)
+	waiter := newWaiter().WithChannel(ws.channel).WithTimeout(timeout)
 	if event != "close" {
 		waiter.RejectOnEvent(ws, "close", errors.New("websocket closed"))
 	}
@@ -119,18 +119,18 @@ func (ws *webSocketImpl) IsClosed() bool {
 	return ws.isClosed
 }
 
-func (ws *webSocketImpl) OnClose(fn func(WebSocket)) {
-	ws.On("close", fn)
+func (ws *webSocketImpl) OnClose(fn func(WebSocket)) Subscription {
+	return ws.On("close", fn)
 }
 
-func (ws *webSocketImpl) OnFrameReceived(fn func(payload []byte)) {
-	ws.On("framereceived", fn)
+func (ws *webSocketImpl) OnFrameReceived(fn func(payload []byte)) Subscription {
+	return ws.On("framereceived", fn)
 }
 
-func (ws *webSocketImpl) OnFrameSent(fn func(payload []byte)) {
-	ws.On("framesent", fn)
+func (ws *webSocketImpl) OnFrameSent(fn func(payload []byte)) Subscription {
+	return ws.On("framesent", fn)
 }
 
-func (ws *webSocketImpl) OnSocketError(fn func(string)) {
-	ws.On("socketerror", fn)
+func (ws *webSocketImpl) OnSocketError(fn func(string)) Subscription {
+	return ws.On("socketerror", fn)
 }
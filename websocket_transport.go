@@ -0,0 +1,154 @@
+package playwright
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/go-jose/go-jose/v3/json"
+)
+
+// websocketTransport speaks the Playwright protocol to a browser server (see
+// [BrowserType.LaunchServer] and `playwright run-server`) directly over a
+// WebSocket, one JSON message per text frame, instead of going through the
+// local driver's LocalUtils.connect.
+type websocketTransport struct {
+	conn   *websocket.Conn
+	slowMo time.Duration
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// dialWebSocketTransport connects to wsEndpoint the way upstream's
+// LocalUtils.connect does. An http(s) endpoint is first resolved to the
+// WebSocket endpoint it advertises at <endpoint>/json.
+func dialWebSocketTransport(wsEndpoint, browserName string, options ...BrowserTypeConnectOptions) (*websocketTransport, error) {
+	var option BrowserTypeConnectOptions
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if option.ExposeNetwork != nil {
+		return nil, errors.New("ExposeNetwork is not supported when connecting without a local driver")
+	}
+	dialCtx := context.Background()
+	if option.Timeout != nil && *option.Timeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(dialCtx, time.Duration(*option.Timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	headers := http.Header{}
+	headers.Set("User-Agent", userAgent())
+	headers.Set("x-playwright-proxy", "")
+	if browserName != "" {
+		headers.Set("x-playwright-browser", browserName)
+	}
+	for name, value := range option.Headers {
+		headers.Set(name, value)
+	}
+
+	endpoint, err := resolveWebSocketEndpoint(dialCtx, wsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	conn, resp, err := websocket.Dial(dialCtx, endpoint, &websocket.DialOptions{HTTPHeader: headers})
+	if err != nil {
+		if errors.Is(dialCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: could not connect to %s: %w", ErrTimeout, endpoint, err)
+		}
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			return nil, fmt.Errorf("could not connect to %s: unexpected status %s", endpoint, resp.Status)
+		}
+		return nil, fmt.Errorf("could not connect to %s: %w", endpoint, err)
+	}
+	// Messages carry screenshots, downloads and traces; do not cap them.
+	conn.SetReadLimit(-1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &websocketTransport{conn: conn, ctx: ctx, cancel: cancel}
+	if option.SlowMo != nil {
+		t.slowMo = time.Duration(*option.SlowMo) * time.Millisecond
+	}
+	return t, nil
+}
+
+// resolveWebSocketEndpoint returns endpoint when it is a ws(s) URL, otherwise
+// the wsEndpoint served as JSON at <endpoint>/json.
+func resolveWebSocketEndpoint(ctx context.Context, endpoint string) (string, error) {
+	if strings.HasPrefix(endpoint, "ws") {
+		return endpoint, nil
+	}
+	fetchURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if !strings.HasSuffix(fetchURL.Path, "/") {
+		fetchURL.Path += "/"
+	}
+	fetchURL.Path += "json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", userAgent())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve websocket url from %s: %w", fetchURL, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve websocket url from %s: %w", fetchURL, err)
+	}
+	var info struct {
+		WSEndpoint string `json:"wsEndpoint"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil || info.WSEndpoint == "" {
+		return "", fmt.Errorf("could not retrieve websocket url from %s: unexpected response %q", fetchURL, body)
+	}
+	return info.WSEndpoint, nil
+}
+
+func (t *websocketTransport) Send(msg map[string]any) error {
+	data, err := encodeMessage(msg)
+	if err != nil {
+		return fmt.Errorf("websocketTransport: %w", err)
+	}
+	return t.conn.Write(t.ctx, websocket.MessageText, data)
+}
+
+func (t *websocketTransport) Poll() (*message, error) {
+	_, data, err := t.conn.Read(t.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read from websocket: %w", err)
+	}
+	msg, err := decodeMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	// Like upstream, slow motion delays every message from the server.
+	if t.slowMo > 0 {
+		time.Sleep(t.slowMo)
+	}
+	return msg, nil
+}
+
+// Close closes the WebSocket. Failures of the close handshake are ignored: the
+// connection is gone either way.
+func (t *websocketTransport) Close() error {
+	_ = t.conn.Close(websocket.StatusNormalClosure, "")
+	t.cancel()
+	return nil
+}
+
+// userAgent mirrors the User-Agent upstream Playwright sends to servers.
+func userAgent() string {
+	return fmt.Sprintf("Playwright/%s (%s; %s) go/%s", playwrightCliVersion, runtime.GOARCH, runtime.GOOS, strings.TrimPrefix(runtime.Version(), "go"))
+}
@@ -0,0 +1,107 @@
+package playwright
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newWebSocketEchoServer serves a WebSocket endpoint at /ws that sends every
+// received message back, and its /json discovery document.
+func newWebSocketEchoServer(t *testing.T, headers chan<- http.Header) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"wsEndpoint": "ws://%s/ws"}`, r.Host)
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		if headers != nil {
+			headers <- r.Header.Clone()
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow() //nolint:errcheck
+		conn.SetReadLimit(-1)
+		for {
+			typ, data, err := conn.Read(context.Background())
+			if err != nil {
+				return
+			}
+			if err := conn.Write(context.Background(), typ, data); err != nil {
+				return
+			}
+		}
+	})
+	return server
+}
+
+func TestWebSocketTransport(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := newWebSocketEchoServer(t, headers)
+	wsEndpoint := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	transport, err := dialWebSocketTransport(wsEndpoint, "firefox", BrowserTypeConnectOptions{
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+	defer transport.Close() //nolint:errcheck
+
+	header := <-headers
+	require.Equal(t, "firefox", header.Get("x-playwright-browser"))
+	require.Equal(t, "Bearer token", header.Get("Authorization"))
+	require.True(t, strings.HasPrefix(header.Get("User-Agent"), "Playwright/"+playwrightCliVersion), header.Get("User-Agent"))
+
+	// Larger than the default read limit of the WebSocket library.
+	payload := strings.Repeat("x", 1<<20)
+	require.NoError(t, transport.Send(map[string]any{"id": 1, "method": "echo", "params": map[string]any{"payload": payload}}))
+	msg, err := transport.Poll()
+	require.NoError(t, err)
+	require.Equal(t, 1, msg.ID)
+	require.Equal(t, "echo", msg.Method)
+	require.Equal(t, payload, msg.Params["payload"])
+
+	require.NoError(t, transport.Close())
+	_, err = transport.Poll()
+	require.Error(t, err)
+}
+
+func TestWebSocketTransportResolvesHTTPEndpoint(t *testing.T) {
+	server := newWebSocketEchoServer(t, nil)
+
+	transport, err := dialWebSocketTransport(server.URL, "chromium", BrowserTypeConnectOptions{
+		SlowMo: Float(50),
+	})
+	require.NoError(t, err)
+	defer transport.Close() //nolint:errcheck
+
+	require.NoError(t, transport.Send(map[string]any{"id": 1}))
+	start := time.Now()
+	_, err = transport.Poll()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestWebSocketTransportUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := dialWebSocketTransport("ws"+strings.TrimPrefix(server.URL, "http"), "chromium")
+	require.ErrorContains(t, err, "unexpected status 404")
+
+	_, err = dialWebSocketTransport(server.URL, "chromium")
+	require.ErrorContains(t, err, "could not retrieve websocket url")
+
+	_, err = dialWebSocketTransport(server.URL, "chromium", BrowserTypeConnectOptions{ExposeNetwork: String("*")})
+	require.ErrorContains(t, err, "ExposeNetwork")
+}
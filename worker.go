@@ -66,12 +66,12 @@ func (w *workerImpl) onClose() {
 	w.Emit("close", w)
 }
 
-func (w *workerImpl) OnClose(fn func(Worker)) {
-	w.On("close", fn)
+func (w *workerImpl) OnClose(fn func(Worker)) Subscription {
+	return w.On("close", fn)
 }
 
-func (w *workerImpl) OnConsole(fn func(ConsoleMessage)) {
-	w.On("console", fn)
+func (w *workerImpl) OnConsole(fn func(ConsoleMessage)) Subscription {
+	return w.On("console", fn)
 }
 
 func newWorker(parent *channelOwner, objectType string, guid string, initializer map[string]any) *workerImpl {
@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"context"
 	"encoding/base64"
 	"io"
 	"os"
@@ -10,13 +11,16 @@ type writableStream struct {
 	channelOwner
 }
 
-func (s *writableStream) Copy(file string) error {
+// Copy writes file to the stream with calls that are part of the API call of
+// ctx.
+func (s *writableStream) Copy(ctx context.Context, file string) error {
 	f, err := os.OpenFile(file, os.O_RDONLY, 0)
 	if err != nil {
 		return err
 	}
 	defer f.Close() //nolint:errcheck
 
+	channel := s.channel.within(ctx)
 	for {
 		buf := make([]byte, defaultCopyBufSize)
 		n, err := f.Read(buf)
@@ -26,14 +30,14 @@ func (s *writableStream) Copy(file string) error {
 		if n == 0 {
 			break
 		}
-		_, err = s.channel.Send("write", map[string]any{
+		_, err = channel.Send("write", map[string]any{
 			"binary": base64.StdEncoding.EncodeToString(buf[:n]),
 		})
 		if err != nil {
 			return err
 		}
 	}
-	_, err = s.channel.Send("close")
+	_, err = channel.Send("close")
 	return err
 }
 